@@ -9,22 +9,42 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
 	"github.com/rasjonell/dashbrew/internal/tui"
 )
 
-var configPath string
+var (
+	configPath string
+	strict     bool
+)
 
 func main() {
-	flag.StringVar(&configPath, "c", "dashboard.json", "Path to dashboard config.")
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(validate(os.Args[2:]))
+	}
+
+	flag.StringVar(&configPath, "c", "dashboard.json", "Path to dashboard config (JSON, YAML or TOML).")
+	flag.BoolVar(&strict, "strict", false, "Refuse to start if the config has any validation problems.")
 	flag.Parse()
 
-	cfg, err := config.LoadConfig(configPath)
+	load := config.LoadConfig
+	if strict {
+		load = config.LoadConfigStrict
+	}
+
+	cfg, err := load(configPath)
 	if err != nil {
 		fmt.Printf("Failed to load config: %v\n", err)
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(tui.New(cfg), tea.WithAltScreen(), tea.WithMouseCellMotion())
+	history, err := data.OpenHistory(cfg.History)
+	if err != nil {
+		fmt.Printf("Failed to open history: %v\n", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(tui.New(cfg, configPath, load, history), tea.WithAltScreen(), tea.WithMouseCellMotion())
 	_, err = p.Run()
 	if err != nil {
 		fmt.Printf("Failed to start program: %v", err)
@@ -32,6 +52,34 @@ func main() {
 	}
 }
 
+// validate implements `dashbrew validate [-c path | path]`.
+func validate(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.StringVar(&configPath, "c", "dashboard.json", "Path to dashboard config (JSON, YAML or TOML).")
+	fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		configPath = fs.Arg(0)
+	}
+
+	_, problems, err := config.Validate(configPath)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		return 1
+	}
+
+	if len(problems) == 0 {
+		fmt.Printf("%s: OK\n", configPath)
+		return 0
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("%s: %s\n", configPath, problem)
+	}
+	fmt.Printf("\n%d problem(s) found\n", len(problems))
+	return 1
+}
+
 func clear() {
 	cmd := exec.Command("clear")
 	cmd.Stdout = os.Stdout
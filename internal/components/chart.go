@@ -1,10 +1,15 @@
 package components
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -12,20 +17,42 @@ import (
 	"github.com/rasjonell/dashbrew/internal/data"
 )
 
+// seriesColors are assigned to the series of a multi-series chart in order.
+var seriesColors = []asciigraph.AnsiColor{
+	asciigraph.DodgerBlue,
+	asciigraph.Orange,
+	asciigraph.LimeGreen,
+	asciigraph.HotPink,
+	asciigraph.Gold,
+	asciigraph.MediumPurple,
+	asciigraph.Turquoise,
+	asciigraph.Tomato,
+}
+
 type ChartComponent struct {
 	baseComponent
-	plotData []float64
+	series []chartSeries
+
+	// plotWidth is the width of the last render, append mode charts without
+	// max_points or window_seconds keep one sample per column.
+	plotWidth int
+}
+
+type chartSeries struct {
+	name   string
+	points []float64
+	times  []time.Time
 }
 
 func newChartComponent(base baseComponent) *ChartComponent {
 	return &ChartComponent{
 		baseComponent: base,
-		plotData:      nil,
+		series:        nil,
 	}
 }
 
 func (c *ChartComponent) View(w, h int, focused bool) string {
-	style, focusedStyle, border := GetBorderStyle(c.styles.Border)
+	style, focusedStyle, border := c.borderStyles()
 	borderStyle := style
 	if focused {
 		borderStyle = focusedStyle
@@ -46,7 +73,7 @@ func (c *ChartComponent) View(w, h int, focused bool) string {
 			lipgloss.Center, lipgloss.Center,
 			WrapContent(errorMsg, chartWidth),
 		)
-	} else if len(c.plotData) == 0 {
+	} else if len(c.series) == 0 {
 		chartContent = lipgloss.Place(chartWidth, chartHeight,
 			lipgloss.Center, lipgloss.Center,
 			"[Loading or No Data]",
@@ -60,13 +87,44 @@ func (c *ChartComponent) View(w, h int, focused bool) string {
 		} else {
 			opts := []asciigraph.Option{
 				asciigraph.Width(chartWidth),
-				asciigraph.Height(chartHeight),
 			}
 			if c.config.Data != nil && c.config.Data.Caption != "" {
 				opts = append(opts, asciigraph.Caption(c.config.Data.Caption))
 			}
 
-			chartContent = asciigraph.Plot(c.plotData, opts...)
+			start, end, timed := timeRange(c.series)
+			plots := make([][]float64, len(c.series))
+			for i, s := range c.series {
+				if timed {
+					plots[i] = resample(s, start, end, chartWidth)
+				} else {
+					plots[i] = s.points
+				}
+			}
+			if timed {
+				chartHeight = max(1, chartHeight-1)
+			}
+
+			if len(c.series) > 1 || c.series[0].name != "" {
+				legends := make([]string, len(c.series))
+				colors := make([]asciigraph.AnsiColor, len(c.series))
+				for i, s := range c.series {
+					legends[i] = s.name
+					colors[i] = seriesColors[i%len(seriesColors)]
+				}
+				opts = append(opts, asciigraph.SeriesLegends(legends...), asciigraph.SeriesColors(colors...))
+
+				// The legend takes a blank line and a line of its own.
+				chartHeight = max(1, chartHeight-2)
+			}
+
+			opts = append(opts, asciigraph.Height(chartHeight))
+			chartContent = asciigraph.PlotMany(plots, opts...)
+			c.plotWidth = chartWidth
+
+			if timed {
+				chartContent = insertTimeAxis(chartContent, chartHeight+1, start, end, chartWidth)
+			}
 		}
 	}
 
@@ -90,18 +148,23 @@ func (c *ChartComponent) SetContent(result data.FetchOutput) (Component, tea.Cmd
 
 	if result.Error() != nil {
 		newInstance.err = result.Error()
-		newInstance.plotData = nil
+		newInstance.series = nil
 	} else {
-		parsedData, parseErr := c.parseDataToChartPoints(result.Output())
+		parsedData, parseErr := parseChartSeries(result.Output())
 		if parseErr != nil {
 			newInstance.err = fmt.Errorf("failed to parse chart data %w", parseErr)
-			newInstance.plotData = nil
+			newInstance.series = nil
 		} else {
 			newInstance.err = nil
 			if c.config.Data.RefreshMode == "append" {
-				newInstance.plotData = append(newInstance.plotData, parsedData...)
+				fetchedAt := result.FetchedAt()
+				if fetchedAt.IsZero() {
+					fetchedAt = time.Now()
+				}
+				newInstance.series = appendSeries(c.series, parsedData, fetchedAt)
+				c.trimSeries(newInstance.series, fetchedAt)
 			} else {
-				newInstance.plotData = parsedData
+				newInstance.series = parsedData
 			}
 		}
 	}
@@ -113,34 +176,389 @@ func (c *ChartComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd
 	return c, true, nil
 }
 
-func (c *ChartComponent) parseDataToChartPoints(rawData string) ([]float64, error) {
+// appendSeries appends every parsed series to the existing series of the
+// same name. Points without a timestamp of their own are spread evenly
+// between the previous sample and fetchedAt. Series that show up for the
+// first time, or are missing from parsed, are padded with NaN so they line
+// up with the others.
+func appendSeries(existing, parsed []chartSeries, fetchedAt time.Time) []chartSeries {
+	merged := make([]chartSeries, len(existing))
+	copy(merged, existing)
+
+	var longest chartSeries
+	for _, s := range merged {
+		if len(s.points) > len(longest.points) {
+			longest = s
+		}
+	}
+
+	for _, p := range parsed {
+		idx := slices.IndexFunc(merged, func(s chartSeries) bool { return s.name == p.name })
+		if idx < 0 {
+			padding := make([]float64, len(longest.points))
+			for i := range padding {
+				padding[i] = math.NaN()
+			}
+			merged = append(merged, chartSeries{
+				name:   p.name,
+				points: padding,
+				times:  slices.Clone(longest.times),
+			})
+			idx = len(merged) - 1
+		}
+
+		s := &merged[idx]
+		s.points = append(slices.Clip(s.points), p.points...)
+		s.times = slices.Clip(s.times)
+
+		if len(p.times) == len(p.points) {
+			s.times = append(s.times, p.times...)
+			continue
+		}
+
+		previous := fetchedAt
+		if len(s.times) > 0 && s.times[len(s.times)-1].Before(fetchedAt) {
+			previous = s.times[len(s.times)-1]
+		}
+		step := fetchedAt.Sub(previous) / time.Duration(len(p.points))
+		for i := range p.points {
+			s.times = append(s.times, fetchedAt.Add(-step*time.Duration(len(p.points)-1-i)))
+		}
+	}
+
+	return padSeries(merged)
+}
+
+// padSeries appends NaN to every series shorter than the longest one, e.g.
+// a series that was missing from the last fetch, so points and timestamps
+// stay aligned across series.
+func padSeries(series []chartSeries) []chartSeries {
+	var longest chartSeries
+	for _, s := range series {
+		if len(s.points) > len(longest.points) {
+			longest = s
+		}
+	}
+
+	for i := range series {
+		s := &series[i]
+		if len(s.points) >= len(longest.points) {
+			continue
+		}
+
+		s.points = slices.Clip(s.points)
+		s.times = slices.Clip(s.times)
+		for n := len(s.points); n < len(longest.points); n++ {
+			s.points = append(s.points, math.NaN())
+			if n < len(longest.times) {
+				s.times = append(s.times, longest.times[n])
+			}
+		}
+	}
+
+	return series
+}
+
+// trimSeries drops points that fall out of the configured window in place.
+// Without max_points or window_seconds the chart keeps as many points as it
+// has columns.
+func (c *ChartComponent) trimSeries(series []chartSeries, now time.Time) {
+	maxPoints := c.config.Data.MaxPoints
+	window := time.Duration(c.config.Data.WindowSeconds) * time.Second
+	if maxPoints == 0 && window == 0 {
+		maxPoints = c.plotWidth
+	}
+
+	for i := range series {
+		s := &series[i]
+
+		drop := 0
+		if window > 0 {
+			cutoff := now.Add(-window)
+			drop, _ = slices.BinarySearchFunc(s.times, cutoff, func(t, cutoff time.Time) int {
+				return t.Compare(cutoff)
+			})
+		}
+		if maxPoints > 0 {
+			drop = max(drop, len(s.points)-maxPoints)
+		}
+
+		s.points = keepLast(s.points, len(s.points)-drop)
+		s.times = keepLast(s.times, len(s.times)-drop)
+	}
+}
+
+// keepLast returns the last n elements of s. The backing array is replaced
+// once it holds twice as many elements as needed so dropped points can be
+// collected.
+func keepLast[T any](s []T, n int) []T {
+	n = max(0, min(n, len(s)))
+	s = s[len(s)-n:]
+	if cap(s) > 2*n+64 {
+		s = slices.Clone(s)
+	}
+	return s
+}
+
+// parseChartSeries accepts a JSON array of numbers or of {t, v} objects, a
+// JSON object of named numbers or arrays, or lines of one or more numeric
+// columns separated by whitespace or commas. A non-numeric first line names
+// the columns and a leading timestamp column dates every line.
+func parseChartSeries(rawData string) ([]chartSeries, error) {
+	trimmed := strings.TrimSpace(rawData)
+
 	var jsonArray []float64
-	errJson := json.Unmarshal([]byte(rawData), &jsonArray)
-	if errJson == nil {
+	if err := json.Unmarshal([]byte(trimmed), &jsonArray); err == nil {
 		if len(jsonArray) == 0 {
 			return nil, fmt.Errorf("Date source returned empty JSON array")
 		}
-		return jsonArray, nil
+		return []chartSeries{{points: jsonArray}}, nil
 	}
 
-	lines := strings.Split(strings.TrimSpace(rawData), "\n")
-	points := make([]float64, 0, len(lines))
+	if strings.HasPrefix(trimmed, "[") {
+		return parseTimedSeries(trimmed)
+	}
 
-	for _, line := range lines {
-		trimmedLine := strings.TrimSpace(line)
-		if trimmedLine == "" {
+	if strings.HasPrefix(trimmed, "{") {
+		return parseNamedSeries(trimmed)
+	}
+
+	lines := strings.Split(trimmed, "\n")
+	var names []string
+	var columns [][]float64
+	var times []time.Time
+	timeColumn, decided := false, false
+
+	for n, line := range lines {
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		if len(fields) == 0 {
 			continue
 		}
-		val, err := strconv.ParseFloat(trimmedLine, 64)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse line '%s' as float64", line)
+
+		if !decided {
+			if n == 0 && !looksLikeTimestamp(fields) && !allNumeric(fields) {
+				names = fields
+				if slices.Contains(timeKeys, strings.ToLower(names[0])) {
+					timeColumn = true
+					names = names[1:]
+				}
+				continue
+			}
+			timeColumn = timeColumn || looksLikeTimestamp(fields)
+			decided = true
+		}
+
+		if timeColumn {
+			t, ok := parseTimestamp(fields[0])
+			if !ok {
+				return nil, fmt.Errorf("failed to parse '%s' as a timestamp", fields[0])
+			}
+			times = append(times, t)
+			fields = fields[1:]
+		}
+
+		values := make([]float64, len(fields))
+		for i, field := range fields {
+			val, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse line '%s' as float64", line)
+			}
+			values[i] = val
+		}
+
+		if columns == nil {
+			columns = make([][]float64, len(values))
+		}
+		if len(values) != len(columns) {
+			return nil, fmt.Errorf("line '%s' has %d values, expected %d", line, len(values), len(columns))
+		}
+		for i, val := range values {
+			columns[i] = append(columns[i], val)
 		}
-		points = append(points, val)
 	}
 
-	if len(points) == 0 {
+	if len(columns) == 0 {
 		return nil, fmt.Errorf("no data points found after parsing")
 	}
 
-	return points, nil
+	series := make([]chartSeries, len(columns))
+	for i, points := range columns {
+		series[i].points = points
+		series[i].times = slices.Clone(times)
+		switch {
+		case i < len(names):
+			series[i].name = names[i]
+		case len(columns) > 1:
+			series[i].name = fmt.Sprintf("series %d", i+1)
+		}
+		sortByTime(&series[i])
+	}
+
+	return series, nil
+}
+
+func allNumeric(fields []string) bool {
+	for _, field := range fields {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNamedSeries decodes a JSON object of series name to a number or an
+// array of numbers, keeping the order of the keys.
+func parseNamedSeries(rawData string) ([]chartSeries, error) {
+	keys, values, err := orderedObject([]byte(rawData))
+	if err != nil {
+		return nil, err
+	}
+
+	var series []chartSeries
+	for i, name := range keys {
+		var points []float64
+		if err := json.Unmarshal(values[i], &points); err != nil {
+			var point float64
+			if err := json.Unmarshal(values[i], &point); err != nil {
+				return nil, fmt.Errorf("series %q is neither a number nor an array of numbers", name)
+			}
+			points = []float64{point}
+		}
+
+		series = append(series, chartSeries{name: name, points: points})
+	}
+
+	if len(series) == 0 {
+		return nil, fmt.Errorf("Data source returned empty JSON object")
+	}
+
+	return series, nil
+}
+
+// parseTimedSeries decodes a JSON array of objects holding a timestamp under
+// "t" and either a single value under "v" or one value per named series.
+func parseTimedSeries(rawData string) ([]chartSeries, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal([]byte(rawData), &objects); err != nil {
+		return nil, err
+	}
+
+	var series []chartSeries
+	for _, obj := range objects {
+		keys, values, err := orderedObject(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		var t time.Time
+		timed := false
+		for i, key := range keys {
+			if slices.Contains(timeKeys, key) {
+				if t, timed = jsonTimestamp(values[i]); !timed {
+					return nil, fmt.Errorf("failed to parse %s as a timestamp", values[i])
+				}
+			}
+		}
+		if !timed {
+			return nil, fmt.Errorf("point %s has no \"t\" field", obj)
+		}
+
+		for i, key := range keys {
+			if slices.Contains(timeKeys, key) {
+				continue
+			}
+
+			var value float64
+			if err := json.Unmarshal(values[i], &value); err != nil {
+				return nil, fmt.Errorf("failed to parse %q of %s as float64", key, obj)
+			}
+
+			name := key
+			if slices.Contains(valueKeys, key) {
+				name = ""
+			}
+
+			idx := slices.IndexFunc(series, func(s chartSeries) bool { return s.name == name })
+			if idx < 0 {
+				series = append(series, chartSeries{name: name})
+				idx = len(series) - 1
+			}
+			series[idx].points = append(series[idx].points, value)
+			series[idx].times = append(series[idx].times, t)
+		}
+	}
+
+	if len(series) == 0 {
+		return nil, fmt.Errorf("Data source returned no timestamped points")
+	}
+
+	for i := range series {
+		sortByTime(&series[i])
+	}
+
+	return series, nil
+}
+
+func jsonTimestamp(raw json.RawMessage) (time.Time, bool) {
+	var epoch float64
+	if err := json.Unmarshal(raw, &epoch); err == nil {
+		return epochTime(epoch), true
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err != nil {
+		return time.Time{}, false
+	}
+	return parseTimestamp(text)
+}
+
+// orderedObject splits a JSON object into its keys and raw values, keeping
+// the order in which they appear.
+func orderedObject(raw []byte) ([]string, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if token, err := dec.Token(); err != nil {
+		return nil, nil, err
+	} else if token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected a JSON object, got %s", raw)
+	}
+
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+
+		keys = append(keys, token.(string))
+		values = append(values, value)
+	}
+
+	return keys, values, nil
+}
+
+func sortByTime(s *chartSeries) {
+	if len(s.times) != len(s.points) || slices.IsSortedFunc(s.times, time.Time.Compare) {
+		return
+	}
+
+	order := make([]int, len(s.points))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return s.times[a].Compare(s.times[b]) })
+
+	points := make([]float64, len(order))
+	times := make([]time.Time, len(order))
+	for i, idx := range order {
+		points[i], times[i] = s.points[idx], s.times[idx]
+	}
+	s.points, s.times = points, times
 }
@@ -0,0 +1,44 @@
+package components
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAppendSeriesKeepsSeriesAligned(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return start.Add(time.Duration(s) * time.Second) }
+
+	series := appendSeries(nil, []chartSeries{
+		{name: "cpu", points: []float64{1}},
+		{name: "mem", points: []float64{10}},
+	}, at(1))
+	series = appendSeries(series, []chartSeries{{name: "cpu", points: []float64{2}}}, at(2))
+	series = appendSeries(series, []chartSeries{{name: "disk", points: []float64{5}}}, at(3))
+
+	want := map[string][]float64{
+		"cpu":  {1, 2, math.NaN()},
+		"mem":  {10, math.NaN(), math.NaN()},
+		"disk": {math.NaN(), math.NaN(), 5},
+	}
+	wantTimes := []time.Time{at(1), at(2), at(3)}
+
+	if len(series) != len(want) {
+		t.Fatalf("got %d series, want %d", len(series), len(want))
+	}
+	for _, s := range series {
+		points := want[s.name]
+		if len(s.points) != len(points) || len(s.times) != len(points) {
+			t.Fatalf("%s: %d points and %d times, want %d", s.name, len(s.points), len(s.times), len(points))
+		}
+		for i, p := range points {
+			if got := s.points[i]; got != p && !(math.IsNaN(got) && math.IsNaN(p)) {
+				t.Errorf("%s[%d] = %v, want %v", s.name, i, got, p)
+			}
+			if !s.times[i].Equal(wantTimes[i]) {
+				t.Errorf("%s time[%d] = %v, want %v", s.name, i, s.times[i], wantTimes[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package components
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// timeKeys name the timestamp of a point in JSON objects and header lines,
+// valueKeys name the value of a single unnamed series.
+var (
+	timeKeys  = []string{"t", "time", "timestamp"}
+	valueKeys = []string{"v", "value"}
+)
+
+// parseTimestamp reads RFC 3339 style dates and unix epochs in seconds or,
+// for values from 1e12 on, milliseconds.
+func parseTimestamp(s string) (time.Time, bool) {
+	if epoch, err := strconv.ParseFloat(s, 64); err == nil {
+		return epochTime(epoch), true
+	}
+
+	for _, layout := range timestampLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func epochTime(epoch float64) time.Time {
+	if epoch >= 1e12 {
+		return time.UnixMilli(int64(epoch))
+	}
+	sec, frac := math.Modf(epoch)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// looksLikeTimestamp reports whether the first of several columns holds a
+// date or an integer that is plausible as a unix epoch.
+func looksLikeTimestamp(fields []string) bool {
+	if len(fields) < 2 {
+		return false
+	}
+
+	if epoch, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+		return epoch >= 1e9 && epoch < 1e13
+	}
+
+	_, ok := parseTimestamp(fields[0])
+	return ok
+}
+
+// timeRange returns the span covered by every series when all of their
+// points carry a timestamp.
+func timeRange(series []chartSeries) (start, end time.Time, ok bool) {
+	for i, s := range series {
+		if len(s.points) == 0 || len(s.times) != len(s.points) {
+			return time.Time{}, time.Time{}, false
+		}
+		if i == 0 || s.times[0].Before(start) {
+			start = s.times[0]
+		}
+		if i == 0 || s.times[len(s.times)-1].After(end) {
+			end = s.times[len(s.times)-1]
+		}
+	}
+	return start, end, len(series) > 0 && end.After(start)
+}
+
+// resample maps the points of s onto width evenly spaced instants between
+// start and end, interpolating linearly between neighbouring samples.
+// Columns outside the range of s are left empty.
+func resample(s chartSeries, start, end time.Time, width int) []float64 {
+	out := make([]float64, width)
+	span := end.Sub(start)
+	last := len(s.times) - 1
+
+	j := 0
+	for col := range out {
+		t := start
+		if width > 1 {
+			t = start.Add(span * time.Duration(col) / time.Duration(width-1))
+		}
+
+		for j < last && !s.times[j+1].After(t) {
+			j++
+		}
+
+		switch {
+		case t.Before(s.times[0]) || t.After(s.times[last]):
+			out[col] = math.NaN()
+		case j < last && s.times[j+1].After(s.times[j]):
+			frac := float64(t.Sub(s.times[j])) / float64(s.times[j+1].Sub(s.times[j]))
+			out[col] = s.points[j] + (s.points[j+1]-s.points[j])*frac
+		default:
+			out[col] = s.points[j]
+		}
+	}
+
+	return out
+}
+
+// timeAxis renders labels for the start, middle and end of the time range
+// so that they line up with the plot area starting at column offset.
+func timeAxis(start, end time.Time, offset, width int) string {
+	layout := "15:04"
+	switch span := end.Sub(start); {
+	case span > 24*time.Hour:
+		layout = "01-02 15:04"
+	case span < 10*time.Minute:
+		layout = "15:04:05"
+	}
+
+	first := start.Local().Format(layout)
+	middle := start.Add(end.Sub(start) / 2).Local().Format(layout)
+	last := end.Local().Format(layout)
+
+	line := []rune(strings.Repeat(" ", offset+width))
+	place := func(label string, at int) {
+		at = max(0, min(at, len(line)-utf8.RuneCountInString(label)))
+		copy(line[at:], []rune(label))
+	}
+
+	place(first, offset)
+	if width >= 3*len(middle)+4 {
+		place(middle, offset+(width-len(middle))/2)
+	}
+	if width >= 2*len(last)+2 {
+		place(last, offset+width-len(last))
+	}
+
+	return strings.TrimRight(string(line), " ")
+}
+
+// insertTimeAxis adds a row of time labels below the first rows lines of an
+// asciigraph plot, aligned with the column after the Y axis.
+func insertTimeAxis(plot string, rows int, start, end time.Time, width int) string {
+	lines := strings.Split(plot, "\n")
+	if len(lines) < rows {
+		return plot
+	}
+
+	offset := 0
+	for i, r := range []rune(lines[0]) {
+		if r == '┤' || r == '┼' {
+			offset = i + 1
+			break
+		}
+	}
+
+	axis := timeAxis(start, end, offset, width)
+	return strings.Join(slices.Insert(lines, rows, axis), "\n")
+}
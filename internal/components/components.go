@@ -2,6 +2,8 @@ package components
 
 import (
 	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -10,29 +12,72 @@ import (
 )
 
 type keyMap struct {
-	Esc       key.Binding
-	Enter     key.Binding
-	Space     key.Binding
-	Delete    key.Binding
-	Backspace key.Binding
-}
-
-var keys = keyMap{
-	Esc: key.NewBinding(
-		key.WithKeys("esc"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-	),
-	Space: key.NewBinding(
-		key.WithKeys(" "),
-	),
-	Delete: key.NewBinding(
-		key.WithKeys("delete", "d", "D"),
-	),
-	Backspace: key.NewBinding(
-		key.WithKeys(tea.KeyBackspace.String()),
-	),
+	Esc          key.Binding
+	Enter        key.Binding
+	Toggle       key.Binding
+	Delete       key.Binding
+	Backspace    key.Binding
+	PrevColumn   key.Binding
+	NextColumn   key.Binding
+	Sort         key.Binding
+	Filter       key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	PageUp       key.Binding
+	PageDown     key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	Top          key.Binding
+	Bottom       key.Binding
+}
+
+// newKeyMap builds the bindings of a component type. Esc, Enter and
+// Backspace edit text inputs and can't be remapped.
+func newKeyMap(bindings map[string][]string) keyMap {
+	return keyMap{
+		Esc: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "confirm"),
+		),
+		Toggle: NewBinding(bindings["toggle"], "toggle done"),
+		Delete: NewBinding(bindings["delete"], "delete item"),
+		Backspace: key.NewBinding(
+			key.WithKeys(tea.KeyBackspace.String()),
+		),
+		PrevColumn: NewBinding(bindings["prev_column"], "previous column"),
+		NextColumn: NewBinding(bindings["next_column"], "next column"),
+		Sort:       NewBinding(bindings["sort"], "cycle sort"),
+		Filter:     NewBinding(bindings["filter"], "filter rows"),
+
+		Up:           NewBinding(bindings["up"], "up"),
+		Down:         NewBinding(bindings["down"], "down"),
+		PageUp:       NewBinding(bindings["page_up"], "page up"),
+		PageDown:     NewBinding(bindings["page_down"], "page down"),
+		HalfPageUp:   NewBinding(bindings["half_page_up"], "½ page up"),
+		HalfPageDown: NewBinding(bindings["half_page_down"], "½ page down"),
+		Top:          NewBinding(bindings["top"], "go to start"),
+		Bottom:       NewBinding(bindings["bottom"], "go to end"),
+	}
+}
+
+// NewBinding creates a binding whose help lists every key it responds to.
+func NewBinding(keys []string, desc string) key.Binding {
+	helpKeys := make([]string, len(keys))
+	for i, k := range keys {
+		if k == " " {
+			k = "space"
+		}
+		helpKeys[i] = k
+	}
+
+	return key.NewBinding(
+		key.WithKeys(keys...),
+		key.WithHelp(strings.Join(helpKeys, "/"), desc),
+	)
 }
 
 type Component interface {
@@ -43,6 +88,10 @@ type Component interface {
 	GetAddInput() string
 	SupportsRefresh() bool
 	Config() *config.Component
+	CapturesInput() bool
+	KeyBindings() []key.Binding
+	SelectedRow() map[string]any
+	SetAlert(color string)
 
 	Init() tea.Cmd
 	View(w, h int, focused bool) string
@@ -51,12 +100,14 @@ type Component interface {
 	HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd)
 }
 
-func NewComponent(cfg *config.Component, styles *config.StyleConfig) Component {
+func NewComponent(cfg *config.Component, styles *config.StyleConfig, bindings config.Keybindings) Component {
 	id := ComponentId(cfg)
 	base := baseComponent{
 		id:     id,
 		config: cfg,
 		styles: styles,
+		keys:   newKeyMap(bindings[cfg.Type]),
+		alert:  &alertBorder{},
 	}
 
 	switch cfg.Type {
@@ -72,6 +123,10 @@ func NewComponent(cfg *config.Component, styles *config.StyleConfig) Component {
 		return newTableComponent(base)
 	case "histogram":
 		return newHistogramComponent(base)
+	case "gauge":
+		return newGaugeComponent(base)
+	case "stat":
+		return newStatComponent(base)
 	default:
 		return newErrorComponent(base, "unknown component type: "+cfg.Type)
 	}
@@ -82,26 +137,62 @@ type baseComponent struct {
 	id     string
 	config *config.Component
 	styles *config.StyleConfig
+	keys   keyMap
+	alert  *alertBorder
 }
 
-func (b baseComponent) Init() tea.Cmd             { return nil }
-func (b baseComponent) GetAddInput() string       { return "" }
-func (b baseComponent) ID() string                { return b.id }
-func (b baseComponent) IsFocusable() bool         { return true }
-func (b baseComponent) SupportsAdd() bool         { return false }
-func (b baseComponent) Config() *config.Component { return b.config }
-func (b baseComponent) Type() string              { return b.config.Type }
+// alertBorder is shared by every copy of a component, so the border color
+// of a firing alert survives SetContent and Update.
+type alertBorder struct {
+	color string
+}
+
+func (b baseComponent) Init() tea.Cmd               { return nil }
+func (b baseComponent) GetAddInput() string         { return "" }
+func (b baseComponent) ID() string                  { return b.id }
+func (b baseComponent) IsFocusable() bool           { return true }
+func (b baseComponent) SupportsAdd() bool           { return false }
+func (b baseComponent) Config() *config.Component   { return b.config }
+func (b baseComponent) Type() string                { return b.config.Type }
+func (b baseComponent) KeyBindings() []key.Binding  { return nil }
+func (b baseComponent) CapturesInput() bool         { return false }
+func (b baseComponent) SelectedRow() map[string]any { return nil }
+
+// SetAlert draws the border in color, an empty color restores the
+// configured border.
+func (b baseComponent) SetAlert(color string) {
+	b.alert.color = color
+}
+
+// borderStyles is GetBorderStyle with the colors of a firing alert applied.
+func (b baseComponent) borderStyles() (normal lipgloss.Style, focused lipgloss.Style, border lipgloss.Border) {
+	normal, focused, border = GetBorderStyle(b.styles.Border)
+	if b.alert != nil && b.alert.color != "" {
+		color := lipgloss.Color(b.alert.color)
+		normal = normal.BorderForeground(color)
+		focused = focused.BorderForeground(color)
+	}
+	return normal, focused, border
+}
 
 func (b baseComponent) SupportsRefresh() bool {
-	return b.config.Data != nil && b.config.Data.RefreshInterval > 0
+	return b.config.Data != nil && (b.config.Data.RefreshInterval > 0 || b.config.Data.Source == "stream")
 }
 
 func (b baseComponent) renderHeader(border lipgloss.Border) string {
+	return b.renderHeaderInfo(border, "")
+}
+
+// renderHeaderInfo renders the title followed by a faint status text.
+func (b baseComponent) renderHeaderInfo(border lipgloss.Border, info string) string {
 	style := lipgloss.NewStyle().Border(border, false, true, true, false).Padding(0, 1)
 	title := b.config.Title
 	if title == "" {
 		title = "Untitled"
 	}
+	if info != "" {
+		title += " " + lipgloss.NewStyle().Faint(true).Render(info)
+	}
 	return style.Render(title)
 }
 
@@ -140,7 +231,7 @@ func (c *errorComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd
 }
 
 func (c *errorComponent) View(width, height int, focused bool) string {
-	style, _, border := GetBorderStyle(c.styles.Border)
+	style, _, border := c.borderStyles()
 
 	innerWidth, innerHeight := CalcWidthHeight(width, height)
 
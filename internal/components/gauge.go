@@ -0,0 +1,189 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const (
+	defaultGaugeMin = 0
+	defaultGaugeMax = 100
+)
+
+type GaugeComponent struct {
+	baseComponent
+	value    float64
+	min      float64
+	max      float64
+	hasValue bool
+}
+
+// gaugeReading is the JSON form of a gauge value, min and max override the
+// range from the component config.
+type gaugeReading struct {
+	Value *float64 `json:"value"`
+	Min   *float64 `json:"min"`
+	Max   *float64 `json:"max"`
+}
+
+func newGaugeComponent(base baseComponent) *GaugeComponent {
+	return &GaugeComponent{
+		baseComponent: base,
+	}
+}
+
+func (c *GaugeComponent) View(w, h int, focused bool) string {
+	style, focusedStyle, border := c.borderStyles()
+	borderStyle := style
+	if focused {
+		borderStyle = focusedStyle
+	}
+
+	innerWidth, innerHeight := CalcWidthHeight(w, h)
+
+	header := c.renderHeader(border)
+	bodyHeight := max(0, innerHeight-lipgloss.Height(header))
+
+	var body string
+	switch {
+	case c.err != nil:
+		errorMsg := fmt.Sprintf("[Error fetching/parsing data]\n%s", c.err.Error())
+		body = WrapContent(errorMsg, innerWidth)
+	case !c.hasValue:
+		body = "[Loading or No Data]"
+	case innerWidth < 10:
+		body = "[Area Too Small]"
+	default:
+		body = c.renderGauge(innerWidth)
+	}
+
+	fullContent := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.Place(innerWidth, bodyHeight, lipgloss.Center, lipgloss.Center, body),
+	)
+
+	return borderStyle.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(fullContent)
+}
+
+func (c *GaugeComponent) renderGauge(width int) string {
+	fraction := (c.value - c.min) / (c.max - c.min)
+	fraction = math.Max(0, math.Min(1, fraction))
+
+	label := fmt.Sprintf("%3.0f%%", fraction*100)
+	barWidth := max(1, width-2-lipgloss.Width(label)-1)
+	filled := int(math.Round(fraction * float64(barWidth)))
+
+	color := ThresholdColor(c.config.Data.Thresholds, c.value, GetColor(c.styles.Global.HighlightedColor, lipgloss.Color("default")))
+	bar := lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Faint(true).Render(strings.Repeat("░", barWidth-filled))
+
+	value := formatNumber(c.value)
+	if c.config.Data.Unit != "" {
+		value += " " + c.config.Data.Unit
+	}
+	rangeText := lipgloss.NewStyle().Faint(true).Render(fmt.Sprintf("(%s – %s)", formatNumber(c.min), formatNumber(c.max)))
+
+	lines := []string{
+		bar + " " + lipgloss.NewStyle().Bold(true).Foreground(color).Render(label),
+		lipgloss.NewStyle().Bold(true).Render(value) + " " + rangeText,
+	}
+	if c.config.Data.Caption != "" {
+		lines = append(lines, c.config.Data.Caption)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
+
+func (c *GaugeComponent) Update(msg tea.Msg) (Component, tea.Cmd) {
+	return c, nil
+}
+
+func (c *GaugeComponent) SetContent(result data.FetchOutput) (Component, tea.Cmd) {
+	newInstance := *c
+
+	if result.Error() != nil {
+		newInstance.err = result.Error()
+		newInstance.hasValue = false
+		return &newInstance, nil
+	}
+
+	value, minValue, maxValue, err := c.parseReading(result.Output())
+	if err != nil {
+		newInstance.err = fmt.Errorf("failed to parse gauge data %w", err)
+		newInstance.hasValue = false
+		return &newInstance, nil
+	}
+
+	newInstance.err = nil
+	newInstance.value = value
+	newInstance.min = minValue
+	newInstance.max = maxValue
+	newInstance.hasValue = true
+
+	return &newInstance, nil
+}
+
+func (c *GaugeComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd) {
+	return c, true, nil
+}
+
+// parseReading accepts a plain number, optionally followed by "%", or a
+// {value, min, max} JSON object. Only the last line of the output is used so
+// scripts and streams can print progress over time.
+func (c *GaugeComponent) parseReading(rawData string) (value, minValue, maxValue float64, err error) {
+	minValue, maxValue = defaultGaugeMin, defaultGaugeMax
+	if c.config.Data.Min != nil {
+		minValue = *c.config.Data.Min
+	}
+	if c.config.Data.Max != nil {
+		maxValue = *c.config.Data.Max
+	}
+
+	lines := strings.Split(strings.TrimSpace(rawData), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if last == "" {
+		return 0, 0, 0, fmt.Errorf("Data source returned no value")
+	}
+
+	if strings.HasPrefix(last, "{") {
+		var reading gaugeReading
+		if err := json.Unmarshal([]byte(last), &reading); err != nil {
+			return 0, 0, 0, err
+		}
+		if reading.Value == nil {
+			return 0, 0, 0, fmt.Errorf("JSON object has no \"value\" field")
+		}
+		if reading.Min != nil {
+			minValue = *reading.Min
+		}
+		if reading.Max != nil {
+			maxValue = *reading.Max
+		}
+		value = *reading.Value
+	} else {
+		value, err = strconv.ParseFloat(strings.TrimSuffix(last, "%"), 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("failed to parse '%s' as float64", last)
+		}
+	}
+
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, 0, 0, fmt.Errorf("'%s' is not a finite number", last)
+	}
+
+	if maxValue <= minValue {
+		return 0, 0, 0, fmt.Errorf("Invalid range %s – %s", formatNumber(minValue), formatNumber(maxValue))
+	}
+
+	return value, minValue, maxValue, nil
+}
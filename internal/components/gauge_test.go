@@ -0,0 +1,43 @@
+package components
+
+import (
+	"testing"
+
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+func TestGaugeParseReading(t *testing.T) {
+	gauge := newGaugeComponent(baseComponent{config: &config.Component{Type: "gauge", Data: &config.DataConfig{}}})
+
+	tests := []struct {
+		raw     string
+		value   float64
+		max     float64
+		wantErr bool
+	}{
+		{raw: "42", value: 42, max: 100},
+		{raw: "starting\n97.5%", value: 97.5, max: 100},
+		{raw: `{"value": 3, "max": 8}`, value: 3, max: 8},
+		{raw: "", wantErr: true},
+		{raw: "busy", wantErr: true},
+		{raw: `{"min": 1}`, wantErr: true},
+		{raw: `{"value": 1, "min": 5, "max": 5}`, wantErr: true},
+		{raw: "nan", wantErr: true},
+		{raw: "NaN%", wantErr: true},
+		{raw: "inf", wantErr: true},
+		{raw: "-Inf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		value, _, maxValue, err := gauge.parseReading(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseReading(%q) = %v, want an error", tt.raw, value)
+			}
+			continue
+		}
+		if err != nil || value != tt.value || maxValue != tt.max {
+			t.Errorf("parseReading(%q) = %v, %v, %v; want %v, %v", tt.raw, value, maxValue, err, tt.value, tt.max)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -27,6 +28,7 @@ func newHistogramComponent(base baseComponent) *HistogramComponent {
 	vp.SetContent("[loading...]")
 	vp.YPosition = 1
 	vp.MouseWheelEnabled = true
+	vp.KeyMap = base.keys.viewportKeyMap(vp.KeyMap)
 
 	return &HistogramComponent{
 		baseComponent: base,
@@ -38,8 +40,12 @@ func newHistogramComponent(base baseComponent) *HistogramComponent {
 	}
 }
 
+func (c *HistogramComponent) KeyBindings() []key.Binding {
+	return viewportKeyBindings(c.viewport.KeyMap)
+}
+
 func (c *HistogramComponent) View(w, h int, focused bool) string {
-	style, focusedStyle, border := GetBorderStyle(c.styles.Border)
+	style, focusedStyle, border := c.borderStyles()
 	borderStyle := style
 	if focused {
 		borderStyle = focusedStyle
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -34,6 +35,7 @@ func newListComponent(base baseComponent) *ListComponent {
 	l.SetShowTitle(false)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(true)
+	l.KeyMap = base.keys.listKeyMap(l.KeyMap)
 
 	return &ListComponent{
 		baseComponent: base,
@@ -41,8 +43,25 @@ func newListComponent(base baseComponent) *ListComponent {
 	}
 }
 
+func (c *ListComponent) KeyBindings() []key.Binding {
+	return listKeyBindings(c.list.KeyMap)
+}
+
+func (c *ListComponent) CapturesInput() bool {
+	return c.list.FilterState() == list.Filtering
+}
+
+// SelectedRow exposes the selected item as {{.value}} to row actions.
+func (c *ListComponent) SelectedRow() map[string]any {
+	item, ok := c.list.SelectedItem().(ListItem)
+	if !ok || c.err != nil {
+		return nil
+	}
+	return map[string]any{"value": item.Val}
+}
+
 func (c *ListComponent) View(w, h int, focused bool) string {
-	style, focusedStyle, border := GetBorderStyle(c.styles.Border)
+	style, focusedStyle, border := c.borderStyles()
 	borderStyle := style
 	if focused {
 		borderStyle = focusedStyle
@@ -99,11 +118,29 @@ func (c *ListComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd)
 	return c, true, nil
 }
 
+// listKeyMap replaces the browsing keys of km with the configured ones.
+func (k keyMap) listKeyMap(km list.KeyMap) list.KeyMap {
+	km.CursorUp, km.CursorDown = k.Up, k.Down
+	km.PrevPage, km.NextPage = k.PageUp, k.PageDown
+	km.GoToStart, km.GoToEnd = k.Top, k.Bottom
+	km.Filter = k.Filter
+	return km
+}
+
+func listKeyBindings(km list.KeyMap) []key.Binding {
+	return []key.Binding{
+		km.CursorUp, km.CursorDown,
+		km.NextPage, km.PrevPage,
+		km.GoToStart, km.GoToEnd,
+		km.Filter, km.ClearFilter,
+	}
+}
+
 func (c *ListComponent) parseDataToListItems(rawData string) []list.Item {
 	var items []list.Item
 
 	switch c.config.Data.Source {
-	case "script":
+	case "script", "stream":
 		lines := strings.Split(strings.TrimSpace(rawData), "\n")
 		for _, line := range lines {
 			if line != "" {
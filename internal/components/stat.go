@@ -0,0 +1,279 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const statHistoryLimit = 120
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// bigDigits is a three row font used to draw the value when the tile is
+// large enough.
+var bigDigits = map[rune][3]string{
+	'0': {"┏━┓", "┃ ┃", "┗━┛"},
+	'1': {" ┓ ", " ┃ ", " ┻ "},
+	'2': {"┏━┓", "┏━┛", "┗━━"},
+	'3': {"━━┓", " ━┫", "━━┛"},
+	'4': {"╻ ╻", "┗━┫", "  ╹"},
+	'5': {"┏━━", "┗━┓", "━━┛"},
+	'6': {"┏━━", "┣━┓", "┗━┛"},
+	'7': {"━━┓", "  ┃", "  ╹"},
+	'8': {"┏━┓", "┣━┫", "┗━┛"},
+	'9': {"┏━┓", "┗━┫", "━━┛"},
+	'-': {"   ", "━━━", "   "},
+	'.': {" ", " ", "."},
+}
+
+type StatComponent struct {
+	baseComponent
+	text     string
+	value    float64
+	numeric  bool
+	hasValue bool
+	unit     string
+	label    string
+	delta    float64
+	hasDelta bool
+	history  []float64
+}
+
+// statReading is the JSON form of a stat, value may be a number or text.
+type statReading struct {
+	Value any    `json:"value"`
+	Unit  string `json:"unit"`
+	Label string `json:"label"`
+}
+
+func newStatComponent(base baseComponent) *StatComponent {
+	return &StatComponent{
+		baseComponent: base,
+	}
+}
+
+func (c *StatComponent) View(w, h int, focused bool) string {
+	style, focusedStyle, border := c.borderStyles()
+	borderStyle := style
+	if focused {
+		borderStyle = focusedStyle
+	}
+
+	innerWidth, innerHeight := CalcWidthHeight(w, h)
+
+	header := c.renderHeader(border)
+	bodyHeight := max(0, innerHeight-lipgloss.Height(header))
+
+	var body string
+	switch {
+	case c.err != nil && !c.hasValue:
+		errorMsg := fmt.Sprintf("[Error fetching/parsing data]\n%s", c.err.Error())
+		body = WrapContent(errorMsg, innerWidth)
+	case !c.hasValue:
+		body = "[Loading or No Data]"
+	default:
+		body = c.renderStat(innerWidth, bodyHeight)
+	}
+
+	fullContent := lipgloss.JoinVertical(lipgloss.Left,
+		header,
+		lipgloss.Place(innerWidth, bodyHeight, lipgloss.Center, lipgloss.Center, body),
+	)
+
+	return borderStyle.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(fullContent)
+}
+
+func (c *StatComponent) renderStat(width, height int) string {
+	color := GetColor(c.styles.Global.HighlightedColor, lipgloss.Color("default"))
+	if c.numeric {
+		color = ThresholdColor(c.config.Data.Thresholds, c.value, color)
+	}
+	valueStyle := lipgloss.NewStyle().Bold(true).Foreground(color)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	var lines []string
+	if c.hasDelta {
+		lines = append(lines, c.renderDelta())
+	}
+	if c.label != "" {
+		lines = append(lines, faint.Render(c.label))
+	}
+	if c.err != nil {
+		lines = append(lines, lipgloss.NewStyle().Foreground(critColor).Render("[stale] "+c.err.Error()))
+	}
+	if c.config.Data.Sparkline && len(c.history) > 1 {
+		lines = append(lines, valueStyle.UnsetBold().Render(sparkline(c.history, width-2)))
+	}
+
+	unit := ""
+	if c.unit != "" {
+		unit = " " + c.unit
+	}
+
+	value := valueStyle.Render(c.text) + faint.Render(unit)
+	if big, ok := bigText(c.text); ok && lipgloss.Width(big)+len(unit) <= width && height >= 3+len(lines) {
+		value = lipgloss.JoinHorizontal(lipgloss.Bottom, valueStyle.Render(big), faint.Render(unit))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, append([]string{value}, lines...)...)
+}
+
+func (c *StatComponent) renderDelta() string {
+	previous := c.value - c.delta
+
+	var change string
+	if previous != 0 {
+		change = fmt.Sprintf(" (%+.1f%%)", c.delta/math.Abs(previous)*100)
+	}
+
+	switch {
+	case c.delta > 0:
+		return lipgloss.NewStyle().Foreground(okColor).Render("▲ " + formatNumber(c.delta) + change)
+	case c.delta < 0:
+		return lipgloss.NewStyle().Foreground(critColor).Render("▼ " + formatNumber(-c.delta) + change)
+	default:
+		return lipgloss.NewStyle().Faint(true).Render("● no change")
+	}
+}
+
+func (c *StatComponent) Update(msg tea.Msg) (Component, tea.Cmd) {
+	return c, nil
+}
+
+func (c *StatComponent) SetContent(result data.FetchOutput) (Component, tea.Cmd) {
+	newInstance := *c
+
+	if result.Error() != nil {
+		newInstance.err = result.Error()
+		return &newInstance, nil
+	}
+
+	reading, err := parseStatReading(result.Output())
+	if err != nil {
+		newInstance.err = fmt.Errorf("failed to parse stat data %w", err)
+		return &newInstance, nil
+	}
+
+	newInstance.err = nil
+	newInstance.hasValue = true
+	newInstance.unit = c.config.Data.Unit
+	if reading.Unit != "" {
+		newInstance.unit = reading.Unit
+	}
+	newInstance.label = c.config.Data.Caption
+	if reading.Label != "" {
+		newInstance.label = reading.Label
+	}
+
+	switch value := reading.Value.(type) {
+	case float64:
+		newInstance.text = formatNumber(value)
+		newInstance.value = value
+		newInstance.hasDelta = c.numeric
+		newInstance.delta = value - c.value
+		newInstance.numeric = true
+		limit := statHistoryLimit
+		if c.config.Data.MaxPoints > 0 {
+			limit = c.config.Data.MaxPoints
+		}
+		newInstance.history = keepLast(append(newInstance.history, value), limit)
+	default:
+		newInstance.text = fmt.Sprint(value)
+		newInstance.numeric = false
+		newInstance.hasDelta = false
+	}
+
+	return &newInstance, nil
+}
+
+func (c *StatComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd) {
+	return c, true, nil
+}
+
+// parseStatReading reads the last line of the output, either as a JSON
+// object with value, unit and label or as plain text. Numeric text is
+// converted so it can be compared against the previous value.
+func parseStatReading(rawData string) (statReading, error) {
+	lines := strings.Split(strings.TrimSpace(rawData), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if last == "" {
+		return statReading{}, fmt.Errorf("Data source returned no value")
+	}
+
+	reading := statReading{Value: last}
+	if strings.HasPrefix(last, "{") {
+		reading = statReading{}
+		if err := json.Unmarshal([]byte(last), &reading); err != nil {
+			return statReading{}, err
+		}
+		if reading.Value == nil {
+			return statReading{}, fmt.Errorf("JSON object has no \"value\" field")
+		}
+	}
+
+	if text, ok := reading.Value.(string); ok {
+		if value, err := strconv.ParseFloat(strings.TrimSpace(text), 64); err == nil {
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				return statReading{}, fmt.Errorf("'%s' is not a finite number", text)
+			}
+			reading.Value = value
+		}
+	}
+
+	return reading, nil
+}
+
+func bigText(text string) (string, bool) {
+	var rows [3]strings.Builder
+	for n, r := range []rune(text) {
+		glyph, ok := bigDigits[r]
+		if !ok {
+			return "", false
+		}
+		for i := range rows {
+			if n > 0 {
+				rows[i].WriteByte(' ')
+			}
+			rows[i].WriteString(glyph[i])
+		}
+	}
+
+	return rows[0].String() + "\n" + rows[1].String() + "\n" + rows[2].String(), true
+}
+
+// sparkline draws the last width values scaled between their min and max.
+func sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		idx := len(sparkBlocks) / 2
+		if hi > lo {
+			idx = int((v - lo) / (hi - lo) * float64(len(sparkBlocks)-1))
+		}
+		idx = max(0, min(idx, len(sparkBlocks)-1))
+		b.WriteRune(sparkBlocks[idx])
+	}
+	return b.String()
+}
@@ -0,0 +1,66 @@
+package components
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestParseStatReading(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    any
+		wantErr bool
+	}{
+		{raw: "42", want: 42.0},
+		{raw: "warming up\n 7.5 ", want: 7.5},
+		{raw: "healthy", want: "healthy"},
+		{raw: `{"value": "12", "unit": "ms"}`, want: 12.0},
+		{raw: `{"value": true}`, want: true},
+		{raw: "", wantErr: true},
+		{raw: `{"unit": "ms"}`, wantErr: true},
+		{raw: "inf", wantErr: true},
+		{raw: "-Infinity", wantErr: true},
+		{raw: "NaN", wantErr: true},
+		{raw: `{"value": "+inf"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		reading, err := parseStatReading(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseStatReading(%q) = %v, want an error", tt.raw, reading.Value)
+			}
+			continue
+		}
+		if err != nil || reading.Value != tt.want {
+			t.Errorf("parseStatReading(%q) = %#v, %v; want %#v", tt.raw, reading.Value, err, tt.want)
+		}
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []float64
+		width  int
+		want   string
+	}{
+		{[]float64{1, 2, 3, 4, 5, 6, 7, 8}, 8, "▁▂▃▄▅▆▇█"},
+		{[]float64{5, 5, 5}, 3, "▅▅▅"},
+		{[]float64{0, 10, 0, 10}, 2, "▁█"},
+		{[]float64{1, 2}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		if got := sparkline(tt.values, tt.width); got != tt.want {
+			t.Errorf("sparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+		}
+	}
+
+	// Values that are not finite must not index outside the blocks.
+	for _, values := range [][]float64{{1, math.Inf(1)}, {math.Inf(-1), 1}, {1, math.NaN(), 2}} {
+		if got := sparkline(values, 10); utf8.RuneCountInString(got) != len(values) {
+			t.Errorf("sparkline(%v) = %q", values, got)
+		}
+	}
+}
@@ -48,6 +48,40 @@ func GetBorderStyle(borderStyles *config.BorderStyleConfig) (normal lipgloss.Sty
 	return style, style.BorderForeground(focusedColor), border
 }
 
+var (
+	okColor   = lipgloss.Color("#5faf5f")
+	warnColor = lipgloss.Color("#d7af00")
+	critColor = lipgloss.Color("#d75f5f")
+)
+
+// ThresholdColor picks the ok, warn or crit color for value. Without
+// thresholds the fallback color is returned.
+func ThresholdColor(t *config.ThresholdConfig, value float64, fallback lipgloss.Color) lipgloss.Color {
+	if t == nil {
+		return fallback
+	}
+
+	descending := t.Warn != nil && t.Crit != nil && *t.Crit < *t.Warn
+	reached := func(limit *float64) bool {
+		if limit == nil {
+			return false
+		}
+		if descending {
+			return value <= *limit
+		}
+		return value >= *limit
+	}
+
+	switch {
+	case reached(t.Crit):
+		return GetColor(t.CritColor, critColor)
+	case reached(t.Warn):
+		return GetColor(t.WarnColor, warnColor)
+	default:
+		return GetColor(t.OkColor, okColor)
+	}
+}
+
 func GetColor(configColor string, defaultColor lipgloss.Color) lipgloss.Color {
 	if len(configColor) == 7 && configColor[0] == '#' {
 		return lipgloss.Color(configColor)
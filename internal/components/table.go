@@ -3,7 +3,9 @@ package components
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -13,28 +15,156 @@ import (
 
 type TableComponent struct {
 	baseComponent
-	table table.Model
-	cols  []table.Column
+	table       table.Model
+	tableStyles table.Styles
+	cols        []table.Column
+
+	// columns are the configured columns or, without any, the ones built
+	// from the header row of the data. rows keeps the order of the source
+	// while visible holds them sorted by sortCol (-1 for none) and narrowed
+	// down by filter, starting at offset on screen. headerRow is the line
+	// of the column titles within the rendered component and is used to
+	// resolve mouse clicks.
+	columns     []*config.ColumnConfig
+	columnRules [][]styleRule
+	rowRules    []styleRule
+	rows        []tableRow
+	visible     []tableRow
+	offset      int
+	sortCol     int
+	sortDesc    bool
+	selectedCol int
+	headerRow   int
+	filter      string
+	filtering   bool
 }
 
 func newTableComponent(base baseComponent) *TableComponent {
+	styles := getTableStyles(base.styles)
 	t := table.New(
 		table.WithColumns([]table.Column{}),
 		table.WithRows([]table.Row{}),
 		table.WithFocused(false),
 		table.WithHeight(5),
-		table.WithStyles(getTableStyles(base.styles)),
+		table.WithStyles(styles),
 	)
+	t.KeyMap = base.keys.tableKeyMap(t.KeyMap)
 
-	return &TableComponent{
+	c := &TableComponent{
 		baseComponent: base,
 		table:         t,
+		tableStyles:   styles,
+		columns:       base.config.Data.Columns,
+		columnRules:   tableColumnRules(base.config.Data.Columns),
+		rowRules:      compileRules(base.config.Data.Rules),
 		cols:          getTableColumns(base.config.Data.Columns, 0),
+		sortCol:       -1,
 	}
+	c.applyDefaultSort()
+
+	return c
+}
+
+func (c *TableComponent) applyDefaultSort() {
+	sortCfg := c.config.Data.Sort
+	if sortCfg == nil {
+		return
+	}
+
+	for i, col := range c.columns {
+		if col != nil && col.Matches(sortCfg.Column) {
+			c.sortCol = i
+			c.selectedCol = i
+			c.sortDesc = sortCfg.Order == "desc"
+			return
+		}
+	}
+}
+
+// setColumns takes over the columns found in the data when none are
+// configured, the default sort is resolved once they are first known.
+func (c *TableComponent) setColumns(columns []*config.ColumnConfig) {
+	if len(c.config.Data.Columns) > 0 || len(columns) == 0 {
+		return
+	}
+
+	first := len(c.columns) == 0
+	c.columns = columns
+	c.columnRules = tableColumnRules(columns)
+	c.selectedCol = min(c.selectedCol, len(columns)-1)
+	if c.sortCol >= len(columns) {
+		c.sortCol, c.sortDesc = -1, false
+	}
+	if first {
+		c.applyDefaultSort()
+	}
+}
+
+// tableKeyMap replaces the cursor keys of km with the configured ones.
+func (k keyMap) tableKeyMap(km table.KeyMap) table.KeyMap {
+	km.LineUp, km.LineDown = k.Up, k.Down
+	km.PageUp, km.PageDown = k.PageUp, k.PageDown
+	km.HalfPageUp, km.HalfPageDown = k.HalfPageUp, k.HalfPageDown
+	km.GotoTop, km.GotoBottom = k.Top, k.Bottom
+	return km
+}
+
+func (c *TableComponent) KeyBindings() []key.Binding {
+	km := c.table.KeyMap
+	return []key.Binding{
+		km.LineUp, km.LineDown,
+		km.PageUp, km.PageDown,
+		km.HalfPageUp, km.HalfPageDown,
+		km.GotoTop, km.GotoBottom,
+		c.keys.PrevColumn, c.keys.NextColumn,
+		c.keys.Sort, c.keys.Filter,
+	}
+}
+
+func (c *TableComponent) CapturesInput() bool {
+	return c.filtering
+}
+
+func (c *TableComponent) SelectedRow() map[string]any {
+	if row, _ := c.selectedTableRow(); row != nil {
+		return row.fields
+	}
+	return nil
+}
+
+func (c *TableComponent) selectedTableRow() (*tableRow, int) {
+	cursor := c.table.Cursor()
+	if cursor < 0 || cursor >= len(c.visible) {
+		return nil, 0
+	}
+	return &c.visible[cursor], cursor
+}
+
+// findRow returns the index of row among the visible rows, so a refresh
+// keeps the cursor, and the selection that linked components follow, on
+// the same row. A row whose values changed is found by its first column,
+// otherwise the cursor stays where it was.
+func (c *TableComponent) findRow(row *tableRow, cursor int) int {
+	if row == nil {
+		return cursor
+	}
+	for i, candidate := range c.visible {
+		if reflect.DeepEqual(candidate.fields, row.fields) {
+			return i
+		}
+	}
+	if len(row.keys) > 0 {
+		for i, candidate := range c.visible {
+			if len(candidate.keys) > 0 && candidate.keys[0] == row.keys[0] {
+				return i
+			}
+		}
+	}
+	return cursor
 }
 
 func (c *TableComponent) View(w, h int, focused bool) string {
-	style, focusedStyle, border := GetBorderStyle(c.styles.Border)
+	style, focusedStyle, border := c.borderStyles()
 	borderStyle := style
 	if focused {
 		borderStyle = focusedStyle
@@ -45,12 +175,22 @@ func (c *TableComponent) View(w, h int, focused bool) string {
 
 	innerWidth, innerHeight := CalcWidthHeight(w, h)
 
-	header := c.renderHeader(border)
+	var info string
+	if c.filter != "" {
+		info = fmt.Sprintf("(%d/%d)", len(c.visible), len(c.rows))
+	}
+	header := c.renderHeaderInfo(border, info)
+	if filterLine := c.renderFilter(); filterLine != "" {
+		header = lipgloss.JoinVertical(lipgloss.Left, header, filterLine)
+	}
 	headerHeight := lipgloss.Height(header)
 
 	// TODO: add a separate Resize() trigger for components
-	c.cols = getTableColumns(c.config.Data.Columns, innerWidth-6)
+	// Cells are padded by a space on both sides.
+	c.cols = getTableColumns(c.columns, innerWidth-2*max(3, len(c.columns)))
+	c.markColumns(focused)
 	c.table.SetColumns(c.cols)
+	c.headerRow = 1 + headerHeight
 
 	tableHeight := max(1, innerHeight-headerHeight-1)
 	c.table.SetHeight(tableHeight)
@@ -62,13 +202,18 @@ func (c *TableComponent) View(w, h int, focused bool) string {
 			lipgloss.Center, lipgloss.Center,
 			WrapContent(errorMsg, innerWidth),
 		)
-	} else if len(c.table.Rows()) == 0 && c.err == nil {
+	} else if len(c.visible) == 0 && len(c.rows) > 0 {
+		tableContent = lipgloss.Place(innerWidth, tableHeight,
+			lipgloss.Center, lipgloss.Center,
+			"[No Matches]",
+		)
+	} else if len(c.visible) == 0 && c.err == nil {
 		tableContent = lipgloss.Place(innerWidth, tableHeight,
 			lipgloss.Center, lipgloss.Center,
 			"[Loading or No Data]",
 		)
 	} else {
-		tableContent = c.table.View()
+		tableContent = c.renderTable(tableHeight)
 	}
 
 	fullContent := lipgloss.JoinVertical(lipgloss.Left,
@@ -82,7 +227,72 @@ func (c *TableComponent) View(w, h int, focused bool) string {
 		Render(fullContent)
 }
 
+// markColumns adds the sort direction to the sorted column title and, while
+// focused, marks the column the sort key applies to.
+func (c *TableComponent) markColumns(focused bool) {
+	for i := range c.cols {
+		if i == c.sortCol {
+			if c.sortDesc {
+				c.cols[i].Title += " ▼"
+			} else {
+				c.cols[i].Title += " ▲"
+			}
+		}
+		if focused && i == c.selectedCol {
+			c.cols[i].Title = "›" + c.cols[i].Title
+		}
+	}
+}
+
+func (c *TableComponent) renderFilter() string {
+	style := lipgloss.NewStyle().Padding(0, 1)
+	switch {
+	case c.filtering:
+		return style.Render("/ " + c.filter + "█")
+	case c.filter != "":
+		return style.Faint(true).Render("/ " + c.filter)
+	default:
+		return ""
+	}
+}
+
 func (c *TableComponent) Update(msg tea.Msg) (Component, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if c.filtering {
+			c.handleFilterKey(msg)
+			return c, nil
+		}
+
+		columns := len(c.columns)
+		switch {
+		case key.Matches(msg, c.keys.Filter):
+			c.filtering = true
+			return c, nil
+		case key.Matches(msg, c.keys.Esc) && c.filter != "":
+			c.setFilter("")
+			return c, nil
+		case key.Matches(msg, c.keys.PrevColumn):
+			c.selectedCol = (c.selectedCol - 1 + columns) % max(1, columns)
+			return c, nil
+		case key.Matches(msg, c.keys.NextColumn):
+			c.selectedCol = (c.selectedCol + 1) % max(1, columns)
+			return c, nil
+		case key.Matches(msg, c.keys.Sort):
+			c.cycleSort(c.selectedCol)
+			return c, nil
+		}
+
+	case tea.MouseMsg:
+		if msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
+			if col, ok := c.columnAt(msg.X, msg.Y); ok {
+				c.selectedCol = col
+				c.cycleSort(col)
+				return c, nil
+			}
+		}
+	}
+
 	var cmd tea.Cmd
 	c.table, cmd = c.table.Update(msg)
 	return c, cmd
@@ -93,27 +303,125 @@ func (c *TableComponent) SetContent(result data.FetchOutput) (Component, tea.Cmd
 
 	if result.Error() != nil {
 		newInstance.err = result.Error()
-		newInstance.table.SetRows([]table.Row{})
+		newInstance.rows = nil
+		newInstance.showRows(nil)
 	} else {
-		parsedRows, parseErr := c.parseDataToTableRows(result.Output(), c.config.Data.Columns)
+		parsedRows, columns, parseErr := c.parseTable(result.Output())
 		if parseErr != nil {
 			newInstance.err = fmt.Errorf("Failed to parse table data: %w", parseErr)
-			newInstance.table.SetRows([]table.Row{})
+			newInstance.rows = nil
+			newInstance.showRows(nil)
 		} else {
+			selected, cursor := c.selectedTableRow()
 			newInstance.err = nil
-			newInstance.table.SetRows(parsedRows)
-			newInstance.table.GotoTop()
+			newInstance.setColumns(columns)
+			newInstance.rows = parsedRows
+			newInstance.showRows(newInstance.visibleRows())
+			newInstance.table.SetCursor(newInstance.findRow(selected, cursor))
 		}
 	}
 
 	return &newInstance, nil
 }
 
+// handleFilterKey edits the filter, which is applied on every keystroke.
+// Enter keeps it and Esc clears it.
+func (c *TableComponent) handleFilterKey(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, c.keys.Esc):
+		c.filtering = false
+		c.setFilter("")
+	case key.Matches(msg, c.keys.Enter):
+		c.filtering = false
+	case key.Matches(msg, c.keys.Backspace):
+		runes := []rune(c.filter)
+		if len(runes) > 0 {
+			c.setFilter(string(runes[:len(runes)-1]))
+		}
+	case msg.Type == tea.KeyRunes:
+		c.setFilter(c.filter + msg.String())
+	case msg.Type == tea.KeySpace:
+		c.setFilter(c.filter + " ")
+	}
+}
+
+func (c *TableComponent) setFilter(filter string) {
+	c.filter = filter
+	c.showRows(c.visibleRows())
+	c.table.GotoTop()
+}
+
+// cycleSort moves the sort of col through ascending, descending and unsorted.
+// Picking another column starts over with ascending.
+func (c *TableComponent) cycleSort(col int) {
+	switch {
+	case c.sortCol != col:
+		c.sortCol, c.sortDesc = col, false
+	case !c.sortDesc:
+		c.sortDesc = true
+	default:
+		c.sortCol, c.sortDesc = -1, false
+	}
+
+	c.showRows(c.visibleRows())
+}
+
+// showRows hands rows to the table. Its columns are replaced first when
+// their number changed, since the table indexes every row by its columns.
+func (c *TableComponent) showRows(rows []tableRow) {
+	if len(c.table.Columns()) != len(c.columns) {
+		c.table.SetRows(nil)
+		c.table.SetColumns(getTableColumns(c.columns, 0))
+	}
+	c.visible = rows
+	c.table.SetRows(tableCells(rows))
+}
+
+// visibleRows returns the rows that match the filter in sort order.
+func (c *TableComponent) visibleRows() []tableRow {
+	columns := c.columns
+	rows := c.rows
+	if c.sortCol >= 0 && c.sortCol < len(columns) {
+		rows = sortRows(rows, c.sortCol, c.sortDesc, columns[c.sortCol].Sort)
+	}
+	return filterRows(rows, columns, c.filter)
+}
+
+// columnAt returns the column whose title is at x, y relative to the
+// component's top left corner.
+func (c *TableComponent) columnAt(x, y int) (int, bool) {
+	if y != c.headerRow {
+		return 0, false
+	}
+
+	offset := 1
+	for i, col := range c.cols {
+		w := col.Width + 2
+		if x >= offset && x < offset+w {
+			return i, true
+		}
+		offset += w
+	}
+	return 0, false
+}
+
 func (c *TableComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd) {
 	return c, true, nil
 }
 
-func (c *TableComponent) parseDataToTableRows(rawData string, columns []*config.ColumnConfig) ([]table.Row, error) {
+// parseTable parses JSON or text output and returns the rows along with the
+// columns they were read for.
+func (c *TableComponent) parseTable(rawData string) ([]tableRow, []*config.ColumnConfig, error) {
+	format := tableFormat(c.config.Data, rawData)
+	if format != "json" {
+		return parseTextTable(c.config.Data, format, rawData, c.config.Data.Columns)
+	}
+
+	rows, err := c.parseDataToTableRows(rawData, c.columns)
+	return rows, c.columns, err
+}
+
+func (c *TableComponent) parseDataToTableRows(rawData string, columns []*config.ColumnConfig) ([]tableRow, error) {
 	if len(columns) == 0 {
 		return nil, fmt.Errorf("Cannot parse table data without column definitions")
 	}
@@ -121,7 +429,6 @@ func (c *TableComponent) parseDataToTableRows(rawData string, columns []*config.
 	var parsedData any
 	err := json.Unmarshal([]byte(rawData), &parsedData)
 	if err != nil {
-		// TODO: parse CSV as well
 		return nil, fmt.Errorf("Failed to parse intput as JSON: %w", err)
 	}
 
@@ -131,10 +438,10 @@ func (c *TableComponent) parseDataToTableRows(rawData string, columns []*config.
 	}
 
 	if len(dataArray) == 0 {
-		return []table.Row{}, nil
+		return []tableRow{}, nil
 	}
 
-	rows := make([]table.Row, 0, len(dataArray))
+	rows := make([]tableRow, 0, len(dataArray))
 	firstElem := dataArray[0]
 
 	switch firstElem.(type) {
@@ -145,15 +452,7 @@ func (c *TableComponent) parseDataToTableRows(rawData string, columns []*config.
 				return nil, fmt.Errorf("Expected array of string arrays, but element at index %d is not an array", i)
 			}
 
-			row := make(table.Row, len(columns))
-			for j := range columns {
-				if j < len(columns) {
-					row[j] = fmt.Sprintf("%v", rowInterface[j])
-				} else {
-					row[j] = ""
-				}
-			}
-			rows = append(rows, row)
+			rows = append(rows, newTableRow(rowInterface, columns, nil))
 		}
 		return rows, nil
 
@@ -164,20 +463,13 @@ func (c *TableComponent) parseDataToTableRows(rawData string, columns []*config.
 				return nil, fmt.Errorf("Expected array of objects, but element at index %d is not an object", i)
 			}
 
-			row := make(table.Row, len(columns))
+			values := make([]any, len(columns))
 			for j, colCfg := range columns {
-				if colCfg.Field == "" {
-					row[j] = ""
-					continue
-				}
-
-				if cellData, keyExists := rowMap[colCfg.Field]; keyExists {
-					row[j] = fmt.Sprintf("%v", cellData)
-				} else {
-					row[j] = ""
+				if colCfg.Field != "" {
+					values[j] = lookupField(rowMap, colCfg.Field)
 				}
 			}
-			rows = append(rows, row)
+			rows = append(rows, newTableRow(values, columns, rowMap))
 		}
 		return rows, nil
 
@@ -0,0 +1,50 @@
+package components
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+// filterRows keeps the rows matching query, a case-insensitive substring
+// searched in every column. A "column:" prefix naming a column label or
+// field limits the search to that column.
+func filterRows(rows []tableRow, columns []*config.ColumnConfig, query string) []tableRow {
+	col, term := parseFilter(columns, query)
+	if term == "" {
+		return rows
+	}
+
+	var filtered []tableRow
+	for _, row := range rows {
+		if rowMatches(row.cells, col, term) {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+func parseFilter(columns []*config.ColumnConfig, query string) (int, string) {
+	if name, term, ok := strings.Cut(query, ":"); ok {
+		for i, col := range columns {
+			if col != nil && (strings.EqualFold(col.Label, name) || strings.EqualFold(col.Field, name)) {
+				return i, strings.ToLower(strings.TrimSpace(term))
+			}
+		}
+	}
+	return -1, strings.ToLower(strings.TrimSpace(query))
+}
+
+func rowMatches(row table.Row, col int, term string) bool {
+	if col >= 0 {
+		return col < len(row) && strings.Contains(strings.ToLower(row[col]), term)
+	}
+
+	for i := range row {
+		if strings.Contains(strings.ToLower(row[i]), term) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,290 @@
+package components
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/oliveagle/jsonpath"
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// tableRow is a parsed row. cells are displayed and searched by the filter,
+// keys hold the unformatted values that rows are sorted by and fields the
+// values by column field and label for row actions.
+type tableRow struct {
+	cells  table.Row
+	keys   []string
+	fields map[string]any
+}
+
+// newTableRow formats values, which line up with columns, for display. The
+// keys of object, the source of a JSON row, become fields as well.
+func newTableRow(values []any, columns []*config.ColumnConfig, object map[string]any) tableRow {
+	row := tableRow{
+		cells:  make(table.Row, len(columns)),
+		keys:   make([]string, len(columns)),
+		fields: make(map[string]any, len(object)+len(columns)),
+	}
+	for k, v := range object {
+		row.fields[k] = v
+	}
+
+	for j, col := range columns {
+		var value any
+		if j < len(values) {
+			value = values[j]
+		}
+		row.cells[j], row.keys[j] = formatCell(value, col)
+
+		for _, name := range []string{col.Field, col.Label} {
+			if _, exists := row.fields[name]; name != "" && !exists {
+				row.fields[name] = value
+			}
+		}
+	}
+	return row
+}
+
+func tableCells(rows []tableRow) []table.Row {
+	cells := make([]table.Row, len(rows))
+	for i, row := range rows {
+		cells[i] = row.cells
+	}
+	return cells
+}
+
+// lookupField resolves field in a JSON object. Keys containing dots are
+// looked up as a whole first so flat objects keep working.
+func lookupField(row map[string]any, field string) any {
+	if value, ok := row[field]; ok {
+		return value
+	}
+
+	if strings.HasPrefix(field, "$") {
+		value, err := jsonpath.JsonPathLookup(row, field)
+		if err != nil {
+			return nil
+		}
+		return value
+	}
+
+	return lookupPath(row, field)
+}
+
+// lookupPath follows a path like "user.tags[0]", negative indexes count
+// from the end of an array.
+func lookupPath(value any, path string) any {
+	for _, part := range strings.Split(path, ".") {
+		name, indexes, _ := strings.Cut(part, "[")
+		if name != "" {
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil
+			}
+			value = object[name]
+		}
+		if indexes == "" {
+			continue
+		}
+
+		for _, index := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			array, ok := value.([]any)
+			i, err := strconv.Atoi(index)
+			if !ok || err != nil {
+				return nil
+			}
+			if i < 0 {
+				i += len(array)
+			}
+			if i < 0 || i >= len(array) {
+				return nil
+			}
+			value = array[i]
+		}
+	}
+	return value
+}
+
+// formatCell returns the text shown for value and the key it sorts by.
+func formatCell(value any, col *config.ColumnConfig) (string, string) {
+	raw := plainValue(value)
+	text, key := raw, raw
+
+	switch col.Format {
+	case "number":
+		if n, ok := parseSortNumber(raw); ok {
+			text = formatPrecision(n, col.Precision)
+		}
+	case "bytes":
+		if n, ok := parseSortNumber(raw); ok {
+			text = humanBytes(n, col.Precision)
+		}
+	case "duration":
+		if d, ok := parseDuration(raw); ok {
+			text = humanDuration(d)
+			key = strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+		}
+	case "relative":
+		if t, ok := parseTimestamp(raw); ok {
+			text = relativeTime(t, time.Now())
+		}
+	case "bool":
+		if b, ok := parseBool(raw); ok {
+			text = "✗"
+			if b {
+				text = "✓"
+			}
+		}
+	}
+
+	return truncateText(text, col.Truncate), key
+}
+
+// plainValue turns a decoded JSON value into text. Numbers never use
+// scientific notation and objects or arrays are shown as compact JSON.
+func plainValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]any, []any:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func formatPrecision(n float64, precision *int) string {
+	if precision == nil {
+		return formatNumber(n)
+	}
+	return strconv.FormatFloat(n, 'f', *precision, 64)
+}
+
+// humanBytes scales n to binary units, with one decimal unless precision
+// says otherwise.
+func humanBytes(n float64, precision *int) string {
+	digits := 1
+	if precision != nil {
+		digits = *precision
+	}
+
+	unit := 0
+	for math.Abs(n) >= 1024 && unit < len(byteUnits)-1 {
+		n /= 1024
+		unit++
+	}
+	if unit == 0 {
+		digits = 0
+	}
+	return strconv.FormatFloat(n, 'f', digits, 64) + " " + byteUnits[unit]
+}
+
+// parseDuration reads a number of seconds or a Go duration like "1h30m".
+func parseDuration(s string) (time.Duration, bool) {
+	if n, ok := parseSortNumber(s); ok {
+		return time.Duration(n * float64(time.Second)), true
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil
+}
+
+// humanDuration shows the two largest units of d, like "3h 12m".
+func humanDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+
+	for i, unit := range units {
+		if d < unit.size {
+			continue
+		}
+
+		text := fmt.Sprintf("%d%s", d/unit.size, unit.name)
+		if i+1 < len(units) {
+			next := units[i+1]
+			if rest := d % unit.size / next.size; rest > 0 {
+				text += fmt.Sprintf(" %d%s", rest, next.name)
+			}
+		}
+		return sign + text
+	}
+
+	return fmt.Sprintf("%s%dms", sign, d.Milliseconds())
+}
+
+// relativeTime describes t in the largest whole unit, like "5m ago" or
+// "in 2d".
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var text string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		text = fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		text = fmt.Sprintf("%dh", int(d.Hours()))
+	case d < 365*24*time.Hour:
+		text = fmt.Sprintf("%dd", int(d.Hours()/24))
+	default:
+		text = fmt.Sprintf("%dy", int(d.Hours()/24/365))
+	}
+
+	if future {
+		return "in " + text
+	}
+	return text + " ago"
+}
+
+func parseBool(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "y", "on", "1":
+		return true, true
+	case "false", "no", "n", "off", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// truncateText cuts s to limit characters, ending in an ellipsis.
+func truncateText(s string, limit int) string {
+	runes := []rune(s)
+	if limit <= 0 || len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
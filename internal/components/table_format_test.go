@@ -0,0 +1,148 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+func intPtr(n int) *int { return &n }
+
+func TestFormatCell(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		col      config.ColumnConfig
+		wantText string
+		wantKey  string
+	}{
+		{"nil", nil, config.ColumnConfig{}, "", ""},
+		{"string", "web-1", config.ColumnConfig{}, "web-1", "web-1"},
+		{"float without exponent", 12345678912.0, config.ColumnConfig{}, "12345678912", "12345678912"},
+		{"bool", true, config.ColumnConfig{}, "true", "true"},
+		{"object", map[string]any{"a": 1.0}, config.ColumnConfig{}, `{"a":1}`, `{"a":1}`},
+		{"array", []any{"x", 2.0}, config.ColumnConfig{}, `["x",2]`, `["x",2]`},
+		{"number precision", 0.123456, config.ColumnConfig{Format: "number", Precision: intPtr(2)}, "0.12", "0.123456"},
+		{"number from text", "1,234.5", config.ColumnConfig{Format: "number", Precision: intPtr(0)}, "1234", "1,234.5"},
+		{"number not numeric", "n/a", config.ColumnConfig{Format: "number"}, "n/a", "n/a"},
+		{"bytes", 1536.0, config.ColumnConfig{Format: "bytes"}, "1.5 KiB", "1536"},
+		{"bytes small", 512.0, config.ColumnConfig{Format: "bytes"}, "512 B", "512"},
+		{"bytes precision", 3.5 * 1024 * 1024 * 1024, config.ColumnConfig{Format: "bytes", Precision: intPtr(2)}, "3.50 GiB", "3758096384"},
+		{"duration seconds", 3725.0, config.ColumnConfig{Format: "duration"}, "1h 2m", "3725"},
+		{"duration text", "90s", config.ColumnConfig{Format: "duration"}, "1m 30s", "90"},
+		{"duration days", "49h", config.ColumnConfig{Format: "duration"}, "2d 1h", "176400"},
+		{"duration sub second", 0.25, config.ColumnConfig{Format: "duration"}, "250ms", "0.25"},
+		{"bool yes", "yes", config.ColumnConfig{Format: "bool"}, "✓", "yes"},
+		{"bool zero", 0.0, config.ColumnConfig{Format: "bool"}, "✗", "0"},
+		{"bool unknown", "maybe", config.ColumnConfig{Format: "bool"}, "maybe", "maybe"},
+		{"truncate", "abcdefgh", config.ColumnConfig{Truncate: 5}, "abcd…", "abcdefgh"},
+		{"truncate runes", "héllo wörld", config.ColumnConfig{Truncate: 6}, "héllo…", "héllo wörld"},
+		{"truncate short", "abc", config.ColumnConfig{Truncate: 5}, "abc", "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, key := formatCell(tt.value, &tt.col)
+			if text != tt.wantText || key != tt.wantKey {
+				t.Errorf("formatCell(%v) = %q, %q; want %q, %q", tt.value, text, key, tt.wantText, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		offset time.Duration
+		want   string
+	}{
+		{-10 * time.Second, "just now"},
+		{-5 * time.Minute, "5m ago"},
+		{-3 * time.Hour, "3h ago"},
+		{-50 * time.Hour, "2d ago"},
+		{-800 * 24 * time.Hour, "2y ago"},
+		{2 * time.Hour, "in 2h"},
+	}
+
+	for _, tt := range tests {
+		if got := relativeTime(now.Add(tt.offset), now); got != tt.want {
+			t.Errorf("relativeTime(%v) = %q, want %q", tt.offset, got, tt.want)
+		}
+	}
+}
+
+func TestLookupField(t *testing.T) {
+	row := map[string]any{
+		"name":     "api",
+		"a.b":      "flat",
+		"user":     map[string]any{"name": "ada", "tags": []any{"x", "y", "z"}},
+		"matrix":   []any{[]any{1.0, 2.0}, []any{3.0, 4.0}},
+		"metrics":  map[string]any{"cpu": 0.5},
+		"nullable": nil,
+	}
+
+	tests := []struct {
+		field string
+		want  any
+	}{
+		{"name", "api"},
+		{"a.b", "flat"},
+		{"user.name", "ada"},
+		{"user.tags[0]", "x"},
+		{"user.tags[-1]", "z"},
+		{"user.tags[3]", nil},
+		{"matrix[1][0]", 3.0},
+		{"$.metrics.cpu", 0.5},
+		{"missing.path", nil},
+		{"name.length", nil},
+		{"nullable", nil},
+	}
+
+	for _, tt := range tests {
+		if got := lookupField(row, tt.field); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("lookupField(%q) = %#v, want %#v", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestNewTableRowFields(t *testing.T) {
+	columns := []*config.ColumnConfig{
+		{Label: "Name", Field: "name"},
+		{Label: "Size", Field: "size", Format: "bytes"},
+	}
+	row := newTableRow([]any{"db", 2048.0}, columns, map[string]any{"name": "db", "size": 2048.0, "id": 7.0})
+
+	if want := []string{"db", "2.0 KiB"}; !reflect.DeepEqual([]string(row.cells), want) {
+		t.Errorf("cells = %q, want %q", row.cells, want)
+	}
+	if want := []string{"db", "2048"}; !reflect.DeepEqual(row.keys, want) {
+		t.Errorf("keys = %q, want %q", row.keys, want)
+	}
+	for field, want := range map[string]any{"name": "db", "Name": "db", "Size": 2048.0, "id": 7.0} {
+		if got := row.fields[field]; got != want {
+			t.Errorf("fields[%q] = %v, want %v", field, got, want)
+		}
+	}
+}
+
+func TestSortRowsByFormattedColumn(t *testing.T) {
+	// Durations are displayed as text but keyed by seconds.
+	col := []string{"2m", "45s", "1h"}
+	rows := make([]tableRow, len(col))
+	for i, v := range col {
+		text, key := formatCell(v, &config.ColumnConfig{Format: "duration"})
+		rows[i] = tableRow{cells: []string{text}, keys: []string{key}}
+	}
+
+	sorted := sortRows(rows, 0, false, "")
+	var got []string
+	for _, row := range sorted {
+		got = append(got, row.cells[0])
+	}
+	if want := []string{"45s", "2m", "1h"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted durations = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,182 @@
+package components
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+// styleRule is a config.StyleRule with its pattern compiled.
+type styleRule struct {
+	*config.StyleRule
+	pattern *regexp.Regexp
+	style   lipgloss.Style
+}
+
+// compileRules skips rules with an invalid pattern, the validator reports
+// them when the config is loaded.
+func compileRules(rules []*config.StyleRule) []styleRule {
+	var compiled []styleRule
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		r := styleRule{StyleRule: rule, style: lipgloss.NewStyle()}
+		if rule.Matches != "" {
+			pattern, err := regexp.Compile(rule.Matches)
+			if err != nil {
+				continue
+			}
+			r.pattern = pattern
+		}
+
+		if rule.Color != "" {
+			r.style = r.style.Foreground(lipgloss.Color(rule.Color))
+		}
+		if rule.Background != "" {
+			r.style = r.style.Background(lipgloss.Color(rule.Background))
+		}
+		if rule.Bold {
+			r.style = r.style.Bold(true)
+		}
+		if rule.Faint {
+			r.style = r.style.Faint(true)
+		}
+		compiled = append(compiled, r)
+	}
+	return compiled
+}
+
+// matches reports whether value meets every condition of the rule. Equality
+// is numeric when both sides are numbers.
+func (r styleRule) matches(value string) bool {
+	value = strings.TrimSpace(value)
+
+	if r.Equals != nil {
+		expected := plainValue(r.Equals)
+		a, okA := parseSortNumber(value)
+		b, okB := parseSortNumber(expected)
+		if okA && okB {
+			if a != b {
+				return false
+			}
+		} else if !strings.EqualFold(value, expected) {
+			return false
+		}
+	}
+
+	if r.pattern != nil && !r.pattern.MatchString(value) {
+		return false
+	}
+
+	if r.Above != nil || r.Below != nil {
+		n, ok := parseSortNumber(value)
+		if !ok || (r.Above != nil && n <= *r.Above) || (r.Below != nil && n >= *r.Below) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applyRules layers the style of every matching rule over base, later
+// rules win.
+func applyRules(base lipgloss.Style, rules []styleRule, value string) lipgloss.Style {
+	for _, rule := range rules {
+		if rule.matches(value) {
+			base = rule.style.Inherit(base)
+		}
+	}
+	return base
+}
+
+// rowStyle returns the style of the row rules that match row.
+func (c *TableComponent) rowStyle(row tableRow, selected bool) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if selected {
+		style = c.tableStyles.Selected
+	}
+
+	for _, rule := range c.rowRules {
+		for i, col := range c.columns {
+			if col != nil && col.Matches(rule.Column) && i < len(row.keys) && rule.matches(row.keys[i]) {
+				style = rule.style.Inherit(style)
+				break
+			}
+		}
+	}
+	return style
+}
+
+// renderRow draws the cells of a row one by one so that rule styles never
+// end up inside text that is measured or truncated.
+func (c *TableComponent) renderRow(row tableRow, selected bool) string {
+	base := c.rowStyle(row, selected)
+
+	cells := make([]string, 0, len(c.cols))
+	for i, col := range c.cols {
+		if col.Width <= 0 {
+			continue
+		}
+
+		var value, key string
+		if i < len(row.cells) {
+			value, key = row.cells[i], row.keys[i]
+		}
+
+		style := base
+		if i < len(c.columnRules) {
+			style = applyRules(base, c.columnRules[i], key)
+		}
+
+		text := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true).
+			Render(runewidth.Truncate(value, col.Width, "…"))
+		cells = append(cells, c.tableStyles.Cell.Inherit(style).Render(text))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, cells...)
+}
+
+// renderTable draws the column titles and the rows around the cursor of the
+// table model, which is only used to move the cursor.
+func (c *TableComponent) renderTable(height int) string {
+	titles := make([]string, 0, len(c.cols))
+	for _, col := range c.cols {
+		if col.Width <= 0 {
+			continue
+		}
+		text := lipgloss.NewStyle().Width(col.Width).MaxWidth(col.Width).Inline(true).
+			Render(runewidth.Truncate(col.Title, col.Width, "…"))
+		titles = append(titles, c.tableStyles.Header.Render(text))
+	}
+	header := lipgloss.JoinHorizontal(lipgloss.Top, titles...)
+
+	visible := max(0, height-lipgloss.Height(header))
+	cursor := c.table.Cursor()
+	if cursor < c.offset {
+		c.offset = cursor
+	}
+	if cursor >= c.offset+visible {
+		c.offset = cursor - visible + 1
+	}
+	c.offset = max(0, min(c.offset, len(c.visible)-visible))
+
+	lines := []string{header}
+	for i := c.offset; i < min(len(c.visible), c.offset+visible); i++ {
+		lines = append(lines, c.renderRow(c.visible[i], i == cursor))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+func tableColumnRules(columns []*config.ColumnConfig) [][]styleRule {
+	rules := make([][]styleRule, len(columns))
+	for i, col := range columns {
+		if col != nil {
+			rules[i] = compileRules(col.Rules)
+		}
+	}
+	return rules
+}
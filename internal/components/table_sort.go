@@ -0,0 +1,116 @@
+package components
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// sortRows returns a copy of rows ordered by the keys of column col. Empty
+// keys always go last, kind is detected from the keys when it is empty.
+func sortRows(rows []tableRow, col int, desc bool, kind string) []tableRow {
+	sorted := slices.Clone(rows)
+	if col < 0 {
+		return sorted
+	}
+
+	if kind == "" {
+		kind = detectSortType(rows, col)
+	}
+
+	slices.SortStableFunc(sorted, func(a, b tableRow) int {
+		x, y := sortKey(a, col), sortKey(b, col)
+		if (x == "") != (y == "") {
+			if x == "" {
+				return 1
+			}
+			return -1
+		}
+
+		order := compareCells(x, y, kind)
+		if desc {
+			return -order
+		}
+		return order
+	})
+
+	return sorted
+}
+
+func sortKey(row tableRow, col int) string {
+	if col >= len(row.keys) {
+		return ""
+	}
+	return strings.TrimSpace(row.keys[col])
+}
+
+// detectSortType picks "number" when every non-empty key is numeric,
+// "date" when every one is a timestamp and "string" otherwise.
+func detectSortType(rows []tableRow, col int) string {
+	numbers, dates, total := 0, 0, 0
+	for _, row := range rows {
+		value := sortKey(row, col)
+		if value == "" {
+			continue
+		}
+		total++
+		if _, ok := parseSortNumber(value); ok {
+			numbers++
+		} else if _, ok := parseTimestamp(value); ok {
+			dates++
+		}
+	}
+
+	switch {
+	case total == 0:
+		return "string"
+	case numbers == total:
+		return "number"
+	case numbers+dates == total:
+		return "date"
+	default:
+		return "string"
+	}
+}
+
+// compareCells orders two cells by kind. Cells that don't parse as kind
+// sort after the ones that do and are compared as text among themselves.
+func compareCells(x, y, kind string) int {
+	switch kind {
+	case "number":
+		a, okA := parseSortNumber(x)
+		b, okB := parseSortNumber(y)
+		if okA && okB {
+			return cmp.Compare(a, b)
+		}
+		if okA != okB {
+			return boolOrder(okA)
+		}
+	case "date":
+		a, okA := parseTimestamp(x)
+		b, okB := parseTimestamp(y)
+		if okA && okB {
+			return a.Compare(b)
+		}
+		if okA != okB {
+			return boolOrder(okA)
+		}
+	}
+
+	return cmp.Compare(strings.ToLower(x), strings.ToLower(y))
+}
+
+func boolOrder(first bool) int {
+	if first {
+		return -1
+	}
+	return 1
+}
+
+// parseSortNumber accepts thousands separators and a trailing percent sign.
+func parseSortNumber(s string) (float64, bool) {
+	s = strings.ReplaceAll(strings.TrimSuffix(s, "%"), ",", "")
+	n, err := strconv.ParseFloat(s, 64)
+	return n, err == nil
+}
@@ -0,0 +1,129 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+func rowsOf(keys ...string) []tableRow {
+	rows := make([]tableRow, len(keys))
+	for i, k := range keys {
+		rows[i] = tableRow{cells: []string{k}, keys: []string{k}}
+	}
+	return rows
+}
+
+func keysOf(rows []tableRow) []string {
+	keys := make([]string, len(rows))
+	for i, row := range rows {
+		keys[i] = row.keys[0]
+	}
+	return keys
+}
+
+func TestSortRows(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		desc bool
+		kind string
+		want []string
+	}{
+		{"numbers detected", []string{"10", "9", "100", "1,000"}, false, "", []string{"9", "10", "100", "1,000"}},
+		{"numbers descending", []string{"10", "9", "100"}, true, "", []string{"100", "10", "9"}},
+		{"percentages", []string{"5%", "50%", "0.5%"}, false, "", []string{"0.5%", "5%", "50%"}},
+		{"strings ignore case", []string{"beta", "Alpha", "gamma"}, false, "", []string{"Alpha", "beta", "gamma"}},
+		{"mixed falls back to strings", []string{"10", "9", "x"}, false, "", []string{"10", "9", "x"}},
+		{"dates", []string{"2024-03-01", "2023-12-31", "2024-01-15"}, false, "", []string{"2023-12-31", "2024-01-15", "2024-03-01"}},
+		{"empty last ascending", []string{"", "2", "1"}, false, "", []string{"1", "2", ""}},
+		{"empty last descending", []string{"", "2", "1"}, true, "", []string{"2", "1", ""}},
+		{"forced number puts text last", []string{"n/a", "3", "20"}, false, "number", []string{"3", "20", "n/a"}},
+		{"forced string", []string{"10", "9", "100"}, false, "string", []string{"10", "100", "9"}},
+		{"stable for equal keys", []string{"b", "a", "B"}, false, "", []string{"a", "b", "B"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := rowsOf(tt.keys...)
+			got := keysOf(sortRows(rows, 0, tt.desc, tt.kind))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortRows(%q) = %q, want %q", tt.keys, got, tt.want)
+			}
+			if !reflect.DeepEqual(keysOf(rows), tt.keys) {
+				t.Errorf("sortRows changed its input to %q", keysOf(rows))
+			}
+		})
+	}
+}
+
+func TestSortRowsWithoutColumn(t *testing.T) {
+	rows := rowsOf("b", "a")
+	if got := keysOf(sortRows(rows, -1, false, "")); !reflect.DeepEqual(got, []string{"b", "a"}) {
+		t.Errorf("unsorted rows = %q", got)
+	}
+}
+
+func newSortTable(t *testing.T, sort *config.SortConfig) Component {
+	t.Helper()
+
+	cfg := &config.Component{Type: "table", Title: "Procs", Data: &config.DataConfig{
+		Source:  "script",
+		Sort:    sort,
+		Columns: []*config.ColumnConfig{{Label: "Name", Field: "name"}, {Label: "CPU", Field: "cpu"}},
+	}}
+	styles := &config.StyleConfig{Border: &config.BorderStyleConfig{}, Global: &config.GlobalStyleConfig{}}
+	comp := NewComponent(cfg, styles, config.DefaultKeybindings())
+	comp.View(60, 10, true)
+	comp, _ = comp.SetContent(data.NewFetchOutput(`[{"name": "b", "cpu": 9}, {"name": "c", "cpu": 10}, {"name": "a", "cpu": 1.5}]`, nil))
+	return comp
+}
+
+func visibleNames(comp Component) []string {
+	var names []string
+	for _, row := range comp.(*TableComponent).visible {
+		names = append(names, row.cells[0])
+	}
+	return names
+}
+
+func TestTableSortKeyCycles(t *testing.T) {
+	comp := newSortTable(t, nil)
+	press := func(k string) {
+		comp, _ = comp.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	}
+
+	press(">")
+	steps := [][]string{
+		{"a", "b", "c"},
+		{"c", "b", "a"},
+		{"b", "c", "a"},
+	}
+	for i, want := range steps {
+		press("s")
+		if got := visibleNames(comp); !reflect.DeepEqual(got, want) {
+			t.Errorf("after %d sorts by CPU: %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestTableDefaultSort(t *testing.T) {
+	tests := []struct {
+		sort *config.SortConfig
+		want []string
+	}{
+		{nil, []string{"b", "c", "a"}},
+		{&config.SortConfig{Column: "name"}, []string{"a", "b", "c"}},
+		{&config.SortConfig{Column: "CPU", Order: "desc"}, []string{"c", "b", "a"}},
+		{&config.SortConfig{Column: "missing"}, []string{"b", "c", "a"}},
+	}
+
+	for _, tt := range tests {
+		if got := visibleNames(newSortTable(t, tt.sort)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sort %+v: %q, want %q", tt.sort, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package components
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+func TestTableKeepsSelectionOnRefresh(t *testing.T) {
+	cfg := &config.Component{ID: "pods", Type: "table", Title: "Pods", Data: &config.DataConfig{
+		Source:  "script",
+		Columns: []*config.ColumnConfig{{Label: "Name", Field: "name"}, {Label: "CPU", Field: "cpu"}},
+	}}
+	var comp Component = NewComponent(cfg, &config.StyleConfig{Border: &config.BorderStyleConfig{}, Global: &config.GlobalStyleConfig{}}, config.DefaultKeybindings())
+
+	refresh := func(output string) {
+		t.Helper()
+		comp, _ = comp.SetContent(data.NewFetchOutput(output, nil))
+	}
+	selected := func() any {
+		if row := comp.SelectedRow(); row != nil {
+			return row["name"]
+		}
+		return nil
+	}
+
+	refresh(`[{"name": "api", "cpu": 1}, {"name": "db", "cpu": 2}, {"name": "web", "cpu": 3}]`)
+	comp.(*TableComponent).table.SetCursor(1)
+
+	tests := []struct {
+		name   string
+		output string
+		want   any
+	}{
+		{"unchanged", `[{"name": "api", "cpu": 1}, {"name": "db", "cpu": 2}, {"name": "web", "cpu": 3}]`, "db"},
+		{"moved", `[{"name": "cache", "cpu": 0}, {"name": "api", "cpu": 1}, {"name": "web", "cpu": 3}, {"name": "db", "cpu": 2}]`, "db"},
+		{"values changed", `[{"name": "api", "cpu": 1}, {"name": "db", "cpu": 9}, {"name": "web", "cpu": 3}]`, "db"},
+		{"row removed", `[{"name": "api", "cpu": 1}, {"name": "web", "cpu": 3}]`, "web"},
+		{"table shrunk", `[{"name": "api", "cpu": 1}]`, "api"},
+	}
+
+	for _, tt := range tests {
+		refresh(tt.output)
+		if got := selected(); got != tt.want {
+			t.Errorf("%s: selected %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestComponentsUseConfiguredNavigationKeys(t *testing.T) {
+	bindings := config.DefaultKeybindings()
+	bindings["list"]["down"] = []string{"n"}
+	bindings["table"]["down"] = []string{"n"}
+
+	styles := &config.StyleConfig{Border: &config.BorderStyleConfig{}, Global: &config.GlobalStyleConfig{}}
+	press := func(comp Component, k string) Component {
+		comp, _ = comp.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+		return comp
+	}
+
+	var list Component = NewComponent(&config.Component{Type: "list", Data: &config.DataConfig{Source: "script"}}, styles, bindings)
+	list.View(40, 10, true)
+	list, _ = list.SetContent(data.NewFetchOutput("a\nb\nc", nil))
+	if list = press(list, "j"); list.SelectedRow()["value"] != "a" {
+		t.Errorf("the unbound default key moved the list to %v", list.SelectedRow()["value"])
+	}
+	if list = press(list, "n"); list.SelectedRow()["value"] != "b" {
+		t.Errorf("the configured key moved the list to %v", list.SelectedRow()["value"])
+	}
+
+	var table Component = NewComponent(&config.Component{Type: "table", Data: &config.DataConfig{
+		Source:  "script",
+		Columns: []*config.ColumnConfig{{Label: "Name", Field: "name"}},
+	}}, styles, bindings)
+	table.View(40, 10, true)
+	table, _ = table.SetContent(data.NewFetchOutput(`[{"name": "a"}, {"name": "b"}]`, nil))
+	if table = press(table, "n"); table.SelectedRow()["name"] != "b" {
+		t.Errorf("the configured key moved the table to %v", table.SelectedRow()["name"])
+	}
+}
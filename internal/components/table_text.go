@@ -0,0 +1,173 @@
+package components
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+// tableFormat returns the configured format or guesses it from the data:
+// JSON for arrays and objects, then TSV or CSV by the separator found on the
+// first line and whitespace aligned columns otherwise.
+func tableFormat(cfg *config.DataConfig, rawData string) string {
+	if cfg.Format != "" {
+		return cfg.Format
+	}
+	if cfg.Delimiter != "" {
+		return "csv"
+	}
+
+	trimmed := strings.TrimSpace(rawData)
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		return "json"
+	}
+
+	firstLine, _, _ := strings.Cut(trimmed, "\n")
+	switch {
+	case strings.Contains(firstLine, "\t"):
+		return "tsv"
+	case strings.Contains(firstLine, ","):
+		return "csv"
+	default:
+		return "whitespace"
+	}
+}
+
+// parseTextTable reads delimited or whitespace aligned text. With a header
+// row, columns are matched to it by field or label and, when none are
+// configured, built from it.
+func parseTextTable(cfg *config.DataConfig, format, rawData string, columns []*config.ColumnConfig) ([]tableRow, []*config.ColumnConfig, error) {
+	var records [][]string
+	var err error
+
+	switch format {
+	case "csv", "tsv":
+		records, err = readDelimited(rawData, delimiterFor(cfg, format))
+	case "whitespace":
+		records = readWhitespace(rawData, cfg.Header, len(columns))
+	default:
+		return nil, nil, fmt.Errorf("Unsupported table format %q", format)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var header []string
+	if cfg.Header && len(records) > 0 {
+		header, records = records[0], records[1:]
+		if len(columns) == 0 {
+			columns = headerColumns(header)
+		}
+	}
+
+	if len(columns) == 0 {
+		return nil, nil, fmt.Errorf("Cannot parse table data without column definitions")
+	}
+
+	indexes := columnIndexes(columns, header)
+	rows := make([]tableRow, 0, len(records))
+	for _, record := range records {
+		values := make([]any, len(columns))
+		for j, idx := range indexes {
+			if idx >= 0 && idx < len(record) {
+				values[j] = strings.TrimSpace(record[idx])
+			}
+		}
+		rows = append(rows, newTableRow(values, columns, nil))
+	}
+
+	return rows, columns, nil
+}
+
+func delimiterFor(cfg *config.DataConfig, format string) rune {
+	if format == "tsv" {
+		return '\t'
+	}
+	if r, _ := utf8.DecodeRuneInString(cfg.Delimiter); cfg.Delimiter != "" && r != utf8.RuneError {
+		return r
+	}
+	return ','
+}
+
+func readDelimited(rawData string, delimiter rune) ([][]string, error) {
+	reader := csv.NewReader(strings.NewReader(rawData))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse input as delimited text: %w", err)
+	}
+	return records, nil
+}
+
+// readWhitespace splits lines on runs of whitespace. The number of columns
+// comes from the header, or the configured columns, and the last column gets
+// the rest of the line so values like commands keep their spaces.
+func readWhitespace(rawData string, header bool, columns int) [][]string {
+	var records [][]string
+	width := columns
+	for _, line := range strings.Split(rawData, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if header && records == nil {
+			fields := strings.Fields(line)
+			width = len(fields)
+			records = append(records, fields)
+			continue
+		}
+		records = append(records, splitFields(line, width))
+	}
+	return records
+}
+
+func splitFields(line string, n int) []string {
+	fields := strings.Fields(line)
+	if n <= 0 || len(fields) <= n {
+		return fields
+	}
+
+	rest := strings.TrimSpace(line)
+	for range n - 1 {
+		rest = strings.TrimLeft(rest[len(strings.Fields(rest)[0]):], " \t")
+	}
+	return append(fields[:n-1:n-1], rest)
+}
+
+func headerColumns(header []string) []*config.ColumnConfig {
+	columns := make([]*config.ColumnConfig, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		columns[i] = &config.ColumnConfig{Label: name, Field: name}
+	}
+	return columns
+}
+
+// columnIndexes maps every column to a position in the records, -1 when a
+// header is present but has no matching name.
+func columnIndexes(columns []*config.ColumnConfig, header []string) []int {
+	indexes := make([]int, len(columns))
+	for j, col := range columns {
+		if header == nil {
+			indexes[j] = j
+			continue
+		}
+
+		indexes[j] = -1
+		for i, name := range header {
+			name = strings.TrimSpace(name)
+			if (col.Field != "" && strings.EqualFold(col.Field, name)) ||
+				(col.Field == "" && strings.EqualFold(col.Label, name)) {
+				indexes[j] = i
+				break
+			}
+		}
+	}
+	return indexes
+}
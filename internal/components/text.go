@@ -3,6 +3,7 @@ package components
 import (
 	"fmt"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -20,6 +21,7 @@ func newTextComponent(base baseComponent) *TextComponent {
 	vp.SetContent("[loading...]")
 	vp.YPosition = 1
 	vp.MouseWheelEnabled = true
+	vp.KeyMap = base.keys.viewportKeyMap(vp.KeyMap)
 	return &TextComponent{
 		baseComponent: base,
 		viewport:      vp,
@@ -27,8 +29,12 @@ func newTextComponent(base baseComponent) *TextComponent {
 	}
 }
 
+func (c *TextComponent) KeyBindings() []key.Binding {
+	return viewportKeyBindings(c.viewport.KeyMap)
+}
+
 func (c *TextComponent) View(w, h int, focused bool) string {
-	style, focusedStyle, border := GetBorderStyle(c.styles.Border)
+	style, focusedStyle, border := c.borderStyles()
 	borderStyle := style
 	if focused {
 		borderStyle = focusedStyle
@@ -75,8 +81,15 @@ func (c *TextComponent) SetContent(result data.FetchOutput) (Component, tea.Cmd)
 		newInstance.content = result.Output()
 	}
 
+	// Streams follow their tail unless the user scrolled up to read.
+	following := c.viewport.AtBottom()
 	newInstance.viewport.SetContent(WrapContent(newInstance.content, newInstance.viewport.Width))
-	newInstance.viewport.GotoTop()
+	switch {
+	case c.config.Data.Source != "stream":
+		newInstance.viewport.GotoTop()
+	case following:
+		newInstance.viewport.GotoBottom()
+	}
 	return &newInstance, nil
 }
 
@@ -91,6 +104,22 @@ func (c *TextComponent) Update(msg tea.Msg) (Component, tea.Cmd) {
 	return c, nil
 }
 
+// viewportKeyMap replaces the scroll keys of km with the configured ones.
+func (k keyMap) viewportKeyMap(km viewport.KeyMap) viewport.KeyMap {
+	km.Up, km.Down = k.Up, k.Down
+	km.PageUp, km.PageDown = k.PageUp, k.PageDown
+	km.HalfPageUp, km.HalfPageDown = k.HalfPageUp, k.HalfPageDown
+	return km
+}
+
+func viewportKeyBindings(km viewport.KeyMap) []key.Binding {
+	return []key.Binding{
+		km.Up, km.Down,
+		km.PageUp, km.PageDown,
+		km.HalfPageUp, km.HalfPageDown,
+	}
+}
+
 func (c *TextComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd) {
 	return c, true, nil
 }
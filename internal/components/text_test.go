@@ -0,0 +1,45 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+func TestTextStreamFollowsTailOnlyAtBottom(t *testing.T) {
+	cfg := &config.Component{Type: "text", Title: "Log", Data: &config.DataConfig{Source: "stream"}}
+	var comp Component = NewComponent(cfg, &config.StyleConfig{Border: &config.BorderStyleConfig{}, Global: &config.GlobalStyleConfig{}}, config.DefaultKeybindings())
+	comp.View(40, 8, true)
+
+	lines := func(n int) data.FetchOutput {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = fmt.Sprintf("line %d", i)
+		}
+		return data.NewFetchOutput(strings.Join(out, "\n"), nil)
+	}
+	text := func() *TextComponent { return comp.(*TextComponent) }
+
+	comp, _ = comp.SetContent(lines(30))
+	if !text().viewport.AtBottom() {
+		t.Fatal("a new stream does not start at its tail")
+	}
+
+	comp, _ = comp.Update(tea.KeyMsg{Type: tea.KeyPgUp})
+	offset := text().viewport.YOffset
+	comp, _ = comp.SetContent(lines(31))
+	if text().viewport.AtBottom() || text().viewport.YOffset != offset {
+		t.Errorf("a new line moved the scrolled view from %d to %d", offset, text().viewport.YOffset)
+	}
+
+	comp, _ = comp.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	comp, _ = comp.Update(tea.KeyMsg{Type: tea.KeyPgDown})
+	comp, _ = comp.SetContent(lines(32))
+	if !text().viewport.AtBottom() {
+		t.Error("the view stopped following the tail after scrolling back down")
+	}
+}
@@ -2,6 +2,7 @@ package components
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -29,11 +30,13 @@ func (i TodoListItem) FilterValue() string { return i.Title() }
 
 type TodoFetchOutput struct {
 	Err       error
+	At        time.Time
 	TodoItems []*data.TodoOutput
 }
 
 func (t *TodoFetchOutput) Output() string            { return "" }
 func (t *TodoFetchOutput) Error() error              { return t.Err }
+func (t *TodoFetchOutput) FetchedAt() time.Time      { return t.At }
 func (t *TodoFetchOutput) Items() []*data.TodoOutput { return t.TodoItems }
 
 type TodoComponent struct {
@@ -53,6 +56,7 @@ func newTodoComponent(base baseComponent) *TodoComponent {
 	l.SetShowHelp(false)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(true)
+	l.KeyMap = base.keys.listKeyMap(l.KeyMap)
 
 	return &TodoComponent{
 		baseComponent: base,
@@ -64,8 +68,16 @@ func newTodoComponent(base baseComponent) *TodoComponent {
 func (c *TodoComponent) SupportsAdd() bool   { return true }
 func (c *TodoComponent) GetAddInput() string { return c.addInput }
 
+func (c *TodoComponent) KeyBindings() []key.Binding {
+	return append([]key.Binding{c.keys.Toggle, c.keys.Delete}, listKeyBindings(c.list.KeyMap)...)
+}
+
+func (c *TodoComponent) CapturesInput() bool {
+	return c.list.FilterState() == list.Filtering
+}
+
 func (c *TodoComponent) View(w, h int, focused bool) string {
-	style, focusedStyle, border := GetBorderStyle(c.styles.Border)
+	style, focusedStyle, border := c.borderStyles()
 	borderStyle := style
 	if focused {
 		borderStyle = focusedStyle
@@ -97,10 +109,10 @@ func (c *TodoComponent) Update(msg tea.Msg) (Component, tea.Cmd) {
 	case tea.KeyMsg:
 		if newInstance.list.FilterState() != list.Filtering {
 			switch {
-			case key.Matches(msg, keys.Space):
+			case key.Matches(msg, c.keys.Toggle):
 				cmd = newInstance.toggleTodoState()
 				cmds = append(cmds, cmd)
-			case key.Matches(msg, keys.Delete):
+			case key.Matches(msg, c.keys.Delete):
 				cmd = newInstance.removeTodo()
 				cmds = append(cmds, cmd)
 			}
@@ -141,17 +153,17 @@ func (c *TodoComponent) HandleAddMode(msg tea.KeyMsg) (Component, bool, tea.Cmd)
 	newInstance := *c
 
 	switch {
-	case key.Matches(msg, keys.Esc):
+	case key.Matches(msg, c.keys.Esc):
 		newInstance.addInput = ""
 		return &newInstance, true, nil
 
-	case key.Matches(msg, keys.Backspace):
+	case key.Matches(msg, c.keys.Backspace):
 		if len(newInstance.addInput) > 0 {
 			newInstance.addInput = newInstance.addInput[:len(newInstance.addInput)-1]
 		}
 		return &newInstance, false, nil
 
-	case key.Matches(msg, keys.Enter):
+	case key.Matches(msg, c.keys.Enter):
 		if newInstance.addInput != "" {
 			cmd := newInstance.addNewTodo()
 			newInstance.addInput = ""
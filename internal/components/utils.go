@@ -2,6 +2,8 @@ package components
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 
 	"github.com/rasjonell/dashbrew/internal/config"
 )
@@ -11,7 +13,7 @@ const (
 )
 
 func ComponentId(comp *config.Component) string {
-	id := comp.ID
+	id := comp.Key()
 	if id == "" {
 		id = fmt.Sprintf("%p", comp)
 	}
@@ -39,3 +41,8 @@ func GetFlex(flex int) int {
 
 	return flex
 }
+
+// formatNumber rounds n to two decimals and drops trailing zeros.
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
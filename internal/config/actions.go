@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// ActionConfig runs Command or opens URL for the selected row of a table or
+// list when Key is pressed. Both are Go templates over the fields of the
+// row, e.g. "kill {{.pid}}", values in commands are quoted for the shell.
+// Confirm asks before running it.
+type ActionConfig struct {
+	Key     string `json:"key"`
+	Label   string `json:"label,omitempty"`
+	Command string `json:"command,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Confirm bool   `json:"confirm,omitempty"`
+}
+
+// actionFuncs are available in action templates, quote turns a value into a
+// single shell word and raw inserts it as it is.
+var actionFuncs = template.FuncMap{
+	"quote": func(value any) string {
+		return "'" + strings.ReplaceAll(fmt.Sprint(value), "'", `'\''`) + "'"
+	},
+	"raw": func(value any) string {
+		return fmt.Sprint(value)
+	},
+}
+
+// shellSafeFuncs end a pipeline whose output needs no further quoting.
+var shellSafeFuncs = []string{"quote", "raw", "urlquery"}
+
+// Keys returns the key of the action, with aliases like "space" resolved.
+func (a *ActionConfig) Keys() []string {
+	if alias, ok := keyAliases[strings.ToLower(a.Key)]; ok {
+		return []string{alias}
+	}
+	return []string{a.Key}
+}
+
+// Title is the label shown in help and messages.
+func (a *ActionConfig) Title() string {
+	switch {
+	case a.Label != "":
+		return a.Label
+	case a.URL != "":
+		return "open " + a.URL
+	default:
+		return a.Command
+	}
+}
+
+// Expand fills the command or URL template with the fields of row. Fields
+// that the row doesn't have are reported as errors.
+func (a *ActionConfig) Expand(row map[string]any) (string, error) {
+	if a.URL != "" {
+		return expandRow(a.URL, row, false)
+	}
+	return expandRow(a.Command, row, true)
+}
+
+// expandRow fills the template text with the fields of row. For shell
+// commands every value is quoted unless its pipeline already ends in quote,
+// raw or urlquery, so a row can never inject shell syntax.
+func expandRow(text string, row map[string]any, shell bool) (string, error) {
+	tmpl, err := parseAction(text)
+	if err != nil {
+		return "", err
+	}
+	if shell {
+		for _, t := range tmpl.Templates() {
+			if t.Tree != nil {
+				quoteActions(t.Tree, t.Tree.Root)
+			}
+		}
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, row); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func parseAction(text string) (*template.Template, error) {
+	return template.New("action").Funcs(actionFuncs).Option("missingkey=error").Parse(text)
+}
+
+// quoteActions pipes the output of every action below node through quote.
+func quoteActions(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			quoteActions(tree, child)
+		}
+	case *parse.IfNode:
+		quoteActions(tree, n.List)
+		quoteActions(tree, n.ElseList)
+	case *parse.RangeNode:
+		quoteActions(tree, n.List)
+		quoteActions(tree, n.ElseList)
+	case *parse.WithNode:
+		quoteActions(tree, n.List)
+		quoteActions(tree, n.ElseList)
+	case *parse.ActionNode:
+		pipe := n.Pipe
+		if len(pipe.Decl) > 0 || len(pipe.Cmds) == 0 {
+			return
+		}
+		last := pipe.Cmds[len(pipe.Cmds)-1]
+		if ident, ok := last.Args[0].(*parse.IdentifierNode); ok && slices.Contains(shellSafeFuncs, ident.Ident) {
+			return
+		}
+		quote := parse.NewIdentifier("quote").SetTree(tree).SetPos(n.Pos)
+		pipe.Cmds = append(pipe.Cmds, &parse.CommandNode{NodeType: parse.NodeCommand, Pos: n.Pos, Args: []parse.Node{quote}})
+	}
+}
+
+// actionConflicts reports action keys that are taken by a global or
+// component binding, or by another action of the same component. Global
+// keys are matched before actions, so such an action could never run.
+func actionConflicts(cfg *DashboardConfig) []string {
+	var problems []string
+	cfg.walkComponents(func(comp *Component, path string) {
+		problems = append(problems, componentActionConflicts(comp, cfg.Keybindings, path)...)
+	})
+	return problems
+}
+
+func componentActionConflicts(comp *Component, bindings Keybindings, path string) []string {
+	var problems []string
+
+	taken := make(map[string]string)
+	for _, scope := range []string{GlobalKeyScope, comp.Type} {
+		for action, keys := range bindings[scope] {
+			// Actions take precedence over moving the cursor.
+			if scope != GlobalKeyScope && slices.Contains(navigationActions, action) {
+				continue
+			}
+			for _, k := range keys {
+				taken[k] = fmt.Sprintf("%s action %q", scope, action)
+			}
+		}
+	}
+
+	for i, action := range comp.Actions {
+		if action == nil || action.Key == "" {
+			continue
+		}
+
+		k := action.Keys()[0]
+		if owner, ok := taken[k]; ok {
+			problems = append(problems, fmt.Sprintf("%s.actions[%d].key: %q is already bound to %s", path, i, displayKey(k), owner))
+		}
+		taken[k] = fmt.Sprintf("actions[%d]", i)
+	}
+
+	return problems
+}
+
+// validateActions checks the templates of the actions of comp. Key
+// conflicts are already rejected when the config is loaded.
+func (v *validator) validateActions(comp *Component, path string) {
+	if len(comp.Actions) > 0 && comp.Type != "table" && comp.Type != "list" {
+		v.report(joinPath(path, "actions"), "only used by table and list components")
+		return
+	}
+
+	for i, action := range comp.Actions {
+		actionPath := fmt.Sprintf("%s.actions[%d]", path, i)
+		if action == nil {
+			continue
+		}
+
+		if action.Key == "" {
+			v.report(joinPath(actionPath, "key"), "missing action key")
+		}
+
+		if (action.Command == "") == (action.URL == "") {
+			v.report(actionPath, "needs either a command or a url")
+			continue
+		}
+		text, field := action.Command, "command"
+		if action.URL != "" {
+			text, field = action.URL, "url"
+		}
+		if _, err := parseAction(text); err != nil {
+			v.report(joinPath(actionPath, field), "invalid template: %v", err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadDoc(t *testing.T, doc string) error {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dashboard.json")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadConfig(path)
+	return err
+}
+
+func TestLoadConfigActionKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "free keys",
+			doc:  component(`{"type": "table", "title": "T", "data": {"source": "script", "command": "ps"}, "actions": [{"key": "enter", "command": "echo"}, {"key": "space", "command": "echo"}]}`),
+		},
+		{
+			name: "global key",
+			doc:  component(`{"type": "table", "title": "T", "data": {"source": "script", "command": "ps"}, "actions": [{"key": "r", "command": "echo"}]}`),
+			want: []string{`layout.component.actions[0].key: "r" is already bound to global action "refresh"`},
+		},
+		{
+			name: "component key",
+			doc:  component(`{"type": "table", "title": "T", "data": {"source": "script", "command": "ps"}, "actions": [{"key": "s", "command": "echo"}]}`),
+			want: []string{`layout.component.actions[0].key: "s" is already bound to table action "sort"`},
+		},
+		{
+			name: "rebound global key is free",
+			doc: `{"keybindings": {"global": {"refresh": ["f5"]}},
+				"layout": {"type": "component", "component": {"type": "list", "title": "L", "data": {"source": "script", "command": "ls"}, "actions": [{"key": "r", "command": "echo"}]}}}`,
+		},
+		{
+			name: "duplicate action keys on a page",
+			doc: `{"pages": [{"title": "P", "layout": {"type": "container", "children": [
+				{"type": "component", "component": {"type": "list", "title": "L", "data": {"source": "script", "command": "ls"},
+					"actions": [{"key": "x", "command": "echo"}, {"key": "x", "url": "http://x"}]}}]}}]}`,
+			want: []string{`pages[0].layout.children[0].component.actions[1].key: "x" is already bound to actions[0]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := loadDoc(t, tt.doc)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandQuotesCommands(t *testing.T) {
+	row := map[string]any{"pid": "1; rm -rf ~", "name": "it's", "n": 3.0, "state": "up", "tags": []any{"a b", "c"}}
+
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"kill {{.pid}}", `kill '1; rm -rf ~'`},
+		{"kill {{quote .pid}}", `kill '1; rm -rf ~'`},
+		{"echo {{.name}}", `echo 'it'\''s'`},
+		{"echo {{raw .n}}", "echo 3"},
+		{"echo {{.n | printf \"%v-x\"}}", "echo '3-x'"},
+		{`{{if eq .state "up"}}stop {{.n}}{{else}}start{{end}}`, "stop '3'"},
+		{"{{with .name}}echo {{.}}{{end}}", `echo 'it'\''s'`},
+		{"{{$p := .pid}}kill {{$p}}", `kill '1; rm -rf ~'`},
+		{"tag{{range .tags}} {{.}}{{end}}", "tag 'a b' 'c'"},
+		{"curl https://x/?q={{urlquery .pid}}", "curl https://x/?q=1%3B+rm+-rf+~"},
+	}
+
+	for _, tt := range tests {
+		action := &ActionConfig{Key: "x", Command: tt.command}
+		got, err := action.Expand(row)
+		if err != nil || got != tt.want {
+			t.Errorf("Expand(%q) = %q, %v; want %q", tt.command, got, err, tt.want)
+		}
+	}
+
+	// URLs are opened without a shell and stay unquoted.
+	url := &ActionConfig{Key: "o", URL: "https://x/{{.state}}"}
+	if got, err := url.Expand(row); err != nil || got != "https://x/up" {
+		t.Errorf("Expand(url) = %q, %v", got, err)
+	}
+
+	linked := &DataConfig{Command: "cat {{.pid}}", URL: "https://x/{{.state}}", SelectionFrom: "t"}
+	expanded, err := linked.ExpandSelection(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expanded.Command != `cat '1; rm -rf ~'` || expanded.URL != "https://x/up" {
+		t.Errorf("ExpandSelection = %q, %q", expanded.Command, expanded.URL)
+	}
+
+	alert := &AlertConfig{Command: "notify {{.value}}"}
+	if got, err := alert.ExpandCommand(map[string]any{"value": "$(reboot)"}); err != nil || got != "notify '$(reboot)'" {
+		t.Errorf("ExpandCommand = %q, %v", got, err)
+	}
+}
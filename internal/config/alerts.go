@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+var alertConditions = []string{"value", "lines", "error"}
+
+// AlertConfig fires when Condition holds for For seconds in a row. "value"
+// compares the last line of the output, or its "value" field when it is a
+// JSON object, "lines" the number of non-empty lines and "error" fires
+// while fetches fail. A firing alert colors the border of the component and
+// runs its hooks, Command and Webhook also run when it resolves.
+type AlertConfig struct {
+	Name      string   `json:"name,omitempty"`
+	Condition string   `json:"condition"`
+	Above     *float64 `json:"above,omitempty"`
+	Below     *float64 `json:"below,omitempty"`
+	Equals    any      `json:"equals,omitempty"`
+	For       int      `json:"for,omitempty"`
+	Color     string   `json:"color,omitempty"`
+	Flash     bool     `json:"flash,omitempty"`
+	Bell      bool     `json:"bell,omitempty"`
+	Notify    bool     `json:"notify,omitempty"`
+	Command   string   `json:"command,omitempty"`
+	Webhook   string   `json:"webhook,omitempty"`
+}
+
+// Title is the name of the alert, or a description of its condition.
+func (a *AlertConfig) Title() string {
+	if a.Name != "" {
+		return a.Name
+	}
+
+	parts := []string{a.Condition}
+	if a.Above != nil {
+		parts = append(parts, "above", fmt.Sprint(*a.Above))
+	}
+	if a.Below != nil {
+		parts = append(parts, "below", fmt.Sprint(*a.Below))
+	}
+	if a.Equals != nil {
+		parts = append(parts, "is", fmt.Sprint(a.Equals))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ExpandCommand fills the hook command template with event, which holds
+// the name, component, title, state and value of the alert.
+func (a *AlertConfig) ExpandCommand(event map[string]any) (string, error) {
+	return expandRow(a.Command, event, true)
+}
+
+func (v *validator) validateAlerts(comp *Component, path string) {
+	if len(comp.Alerts) > 0 && comp.Type == "todo" {
+		v.report(joinPath(path, "alerts"), "not used by todo components")
+		return
+	}
+
+	for i, alert := range comp.Alerts {
+		alertPath := fmt.Sprintf("%s.alerts[%d]", path, i)
+		if alert == nil {
+			continue
+		}
+
+		switch {
+		case alert.Condition == "":
+			v.report(joinPath(alertPath, "condition"), "missing alert condition, expected one of %s", quoteList(alertConditions))
+		case !slices.Contains(alertConditions, alert.Condition):
+			v.report(joinPath(alertPath, "condition"), "unknown alert condition %q, expected one of %s", alert.Condition, quoteList(alertConditions))
+		case alert.Condition == "error":
+			compared := map[string]bool{
+				"above":  alert.Above != nil,
+				"below":  alert.Below != nil,
+				"equals": alert.Equals != nil,
+			}
+			for _, key := range sortedKeys(compared) {
+				if compared[key] {
+					v.report(joinPath(alertPath, key), "not used by error alerts")
+				}
+			}
+		case alert.Condition == "lines" && alert.Equals != nil:
+			v.report(joinPath(alertPath, "equals"), "only used by value alerts")
+		case alert.Above == nil && alert.Below == nil && alert.Equals == nil:
+			v.report(alertPath, "set at least one of above, below or equals")
+		}
+
+		if alert.For < 0 {
+			v.report(joinPath(alertPath, "for"), "must not be negative")
+		}
+		v.validateColor(alert.Color, joinPath(alertPath, "color"))
+
+		if alert.Command != "" {
+			if _, err := parseAction(alert.Command); err != nil {
+				v.report(joinPath(alertPath, "command"), "invalid template: %v", err)
+			}
+		}
+		if alert.Webhook != "" {
+			if u, err := url.Parse(alert.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				v.report(joinPath(alertPath, "webhook"), "must be an http or https url")
+			}
+		}
+	}
+}
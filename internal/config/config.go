@@ -1,13 +1,99 @@
 package config
 
 import (
-	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 )
 
 type DashboardConfig struct {
-	Layout *LayoutNode  `json:"layout"`
-	Style  *StyleConfig `json:"style,omitempty"`
+	Layout      *LayoutNode       `json:"layout,omitempty"`
+	Pages       []*PageConfig     `json:"pages,omitempty"`
+	Style       *StyleConfig      `json:"style,omitempty"`
+	Variables   map[string]string `json:"variables,omitempty"`
+	Keybindings Keybindings       `json:"keybindings,omitempty"`
+	History     *HistoryConfig    `json:"history,omitempty"`
+}
+
+// HistoryConfig enables the on-disk history of fetch results. Dir defaults
+// to dashbrew/history under the XDG state directory.
+type HistoryConfig struct {
+	Dir            string `json:"dir,omitempty"`
+	RetentionHours int    `json:"retention_hours,omitempty"`
+	MaxRecords     int    `json:"max_records,omitempty"`
+}
+
+// RecordsHistory reports whether fetch results of comp are recorded in the
+// history store, which needs an id to file them under.
+func (c *Component) RecordsHistory() bool {
+	return c.ID != "" && c.Data != nil && c.Type != "todo"
+}
+
+// RestoresHistory reports whether the recorded results of comp are replayed
+// on startup.
+func (c *Component) RestoresHistory() bool {
+	return c.ID != "" && c.usesHistory()
+}
+
+// usesHistory reports whether the component type builds up state over
+// several fetches, which is what the history store restores.
+func (c *Component) usesHistory() bool {
+	if c.Data == nil {
+		return false
+	}
+	return c.Type == "stat" || (c.Type == "chart" && c.Data.RefreshMode == "append")
+}
+
+// Key identifies the component, its ID or else its path in the layout like
+// "pages[0].layout.children[1].component". Both stay the same when the
+// config is reloaded.
+func (c *Component) Key() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return c.path
+}
+
+// walkComponents calls fn for every component of the layout and pages with
+// its path in the config.
+func (c *DashboardConfig) walkComponents(fn func(comp *Component, path string)) {
+	var walk func(node *LayoutNode, path string)
+	walk = func(node *LayoutNode, path string) {
+		if node == nil {
+			return
+		}
+		if node.Component != nil {
+			fn(node.Component, joinPath(path, "component"))
+		}
+		for i, child := range node.Children {
+			walk(child, fmt.Sprintf("%s.children[%d]", path, i))
+		}
+	}
+
+	walk(c.Layout, "layout")
+	for i, page := range c.Pages {
+		if page != nil {
+			walk(page.Layout, fmt.Sprintf("pages[%d].layout", i))
+		}
+	}
+}
+
+// PageConfig is a single tab of a dashboard with its own layout tree.
+type PageConfig struct {
+	Title  string      `json:"title"`
+	Layout *LayoutNode `json:"layout"`
+}
+
+// PageList returns the pages of the dashboard, a config with a top level
+// layout is a single untitled page.
+func (c *DashboardConfig) PageList() []*PageConfig {
+	if len(c.Pages) > 0 {
+		return c.Pages
+	}
+	if c.Layout == nil {
+		return nil
+	}
+	return []*PageConfig{{Layout: c.Layout}}
 }
 
 type LayoutNode struct {
@@ -20,29 +106,118 @@ type LayoutNode struct {
 }
 
 type Component struct {
-	Type  string      `json:"type"`
-	Title string      `json:"title"`
-	Data  *DataConfig `json:"data"`
-	ID    string      `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Title   string          `json:"title"`
+	Data    *DataConfig     `json:"data"`
+	ID      string          `json:"id,omitempty"`
+	Actions []*ActionConfig `json:"actions,omitempty"`
+	Alerts  []*AlertConfig  `json:"alerts,omitempty"`
+
+	// path is where the component sits in the layout, it identifies
+	// components without an ID across reloads.
+	path string
 }
 
 type DataConfig struct {
-	Source          string          `json:"source"`
-	JSONPath        string          `json:"json_path"`
-	X               string          `json:"x,omitempty"`
-	Y               string          `json:"y,omitempty"`
-	URL             string          `json:"url,omitempty"`
-	Command         string          `json:"command,omitempty"`
-	Caption         string          `json:"caption,omitempty"`
-	Columns         []*ColumnConfig `json:"columns,omitempty"`
-	RefreshMode     string          `json:"refresh_mode,omitempty"`
-	RefreshInterval int             `json:"refresh_interval,omitempty"`
+	Source          string            `json:"source"`
+	JSONPath        string            `json:"json_path"`
+	X               string            `json:"x,omitempty"`
+	Y               string            `json:"y,omitempty"`
+	URL             string            `json:"url,omitempty"`
+	Method          string            `json:"method,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	Query           map[string]string `json:"query,omitempty"`
+	Body            any               `json:"body,omitempty"`
+	Auth            *AuthConfig       `json:"auth,omitempty"`
+	TLS             *TLSConfig        `json:"tls,omitempty"`
+	Command         string            `json:"command,omitempty"`
+	Caption         string            `json:"caption,omitempty"`
+	Columns         []*ColumnConfig   `json:"columns,omitempty"`
+	Format          string            `json:"format,omitempty"`
+	Delimiter       string            `json:"delimiter,omitempty"`
+	Header          bool              `json:"header,omitempty"`
+	RefreshMode     string            `json:"refresh_mode,omitempty"`
+	RefreshInterval int               `json:"refresh_interval,omitempty"`
+	MaxPoints       int               `json:"max_points,omitempty"`
+	WindowSeconds   int               `json:"window_seconds,omitempty"`
+	Timeout         int               `json:"timeout,omitempty"`
+	RestartDelay    int               `json:"restart_delay,omitempty"`
+	StreamBuffer    int               `json:"stream_buffer,omitempty"`
+	Min             *float64          `json:"min,omitempty"`
+	Max             *float64          `json:"max,omitempty"`
+	Unit            string            `json:"unit,omitempty"`
+	Thresholds      *ThresholdConfig  `json:"thresholds,omitempty"`
+	Sparkline       bool              `json:"sparkline,omitempty"`
+	Sort            *SortConfig       `json:"sort,omitempty"`
+	Rules           []*StyleRule      `json:"rules,omitempty"`
+	SelectionFrom   string            `json:"selection_from,omitempty"`
+}
+
+// SortConfig is the initial sort of a table, Column matches a column label
+// or field and Order is "asc" (default) or "desc".
+type SortConfig struct {
+	Column string `json:"column"`
+	Order  string `json:"order,omitempty"`
+}
+
+// ThresholdConfig colors a value by the level it reached. When Crit is
+// below Warn lower values are treated as worse.
+type ThresholdConfig struct {
+	Warn      *float64 `json:"warn,omitempty"`
+	Crit      *float64 `json:"crit,omitempty"`
+	OkColor   string   `json:"ok_color,omitempty"`
+	WarnColor string   `json:"warn_color,omitempty"`
+	CritColor string   `json:"crit_color,omitempty"`
+}
+
+type AuthConfig struct {
+	Type     string `json:"type"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
 }
 
+type TLSConfig struct {
+	CAFile             string `json:"ca_file,omitempty"`
+	CertFile           string `json:"cert_file,omitempty"`
+	KeyFile            string `json:"key_file,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+// ColumnConfig describes a table column. Field is a key, a dotted path like
+// "user.tags[0]" or a JSONPath starting with "$". Sort forces how values are
+// compared ("number", "date" or "string") instead of detecting it, Format
+// picks how they are displayed and Truncate cuts them to a number of
+// characters.
 type ColumnConfig struct {
-	Label string `json:"label"`
-	Field string `json:"field,omitempty"`
-	Flex  int    `json:"flex,omitempty"`
+	Label     string       `json:"label"`
+	Field     string       `json:"field,omitempty"`
+	Flex      int          `json:"flex,omitempty"`
+	Sort      string       `json:"sort,omitempty"`
+	Format    string       `json:"format,omitempty"`
+	Precision *int         `json:"precision,omitempty"`
+	Truncate  int          `json:"truncate,omitempty"`
+	Rules     []*StyleRule `json:"rules,omitempty"`
+}
+
+// StyleRule styles a table cell, or a whole row for rules on the table,
+// when the value matches every condition that is set. Row rules test the
+// value of Column, a column label or field.
+type StyleRule struct {
+	Column     string   `json:"column,omitempty"`
+	Equals     any      `json:"equals,omitempty"`
+	Matches    string   `json:"matches,omitempty"`
+	Above      *float64 `json:"above,omitempty"`
+	Below      *float64 `json:"below,omitempty"`
+	Color      string   `json:"color,omitempty"`
+	Background string   `json:"background,omitempty"`
+	Bold       bool     `json:"bold,omitempty"`
+	Faint      bool     `json:"faint,omitempty"`
+}
+
+// Matches reports whether name refers to the column by label or field.
+func (c *ColumnConfig) Matches(name string) bool {
+	return name != "" && (c.Label == name || c.Field == name)
 }
 
 type StyleConfig struct {
@@ -62,14 +237,51 @@ type BorderStyleConfig struct {
 }
 
 func LoadConfig(path string) (*DashboardConfig, error) {
-	file, err := os.ReadFile(path)
+	cfg, _, err := load(path)
+	return cfg, err
+}
+
+// LoadConfigStrict loads the config like LoadConfig but fails when
+// Validate reports any problem.
+func LoadConfigStrict(path string) (*DashboardConfig, error) {
+	cfg, problems, err := Validate(path)
 	if err != nil {
 		return nil, err
 	}
 
-	var cfg *DashboardConfig
-	if err := json.Unmarshal(file, &cfg); err != nil {
-		return nil, err
+	if len(problems) > 0 {
+		return nil, &ValidationError{Path: path, Problems: problems}
+	}
+
+	return cfg, nil
+}
+
+func load(path string) (*DashboardConfig, []byte, error) {
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, doc, err := decodeConfig(path, file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := interpolate(cfg); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cfg.Keybindings, err = resolveKeybindings(cfg.Keybindings)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	cfg.walkComponents(func(comp *Component, path string) {
+		comp.path = path
+	})
+
+	if problems := actionConflicts(cfg); len(problems) > 0 {
+		return nil, nil, fmt.Errorf("%s: invalid action keys:\n  %s", path, strings.Join(problems, "\n  "))
 	}
 
 	if cfg.Style == nil {
@@ -84,5 +296,5 @@ func LoadConfig(path string) (*DashboardConfig, error) {
 		cfg.Style.Border = &BorderStyleConfig{}
 	}
 
-	return cfg, nil
+	return cfg, doc, nil
 }
@@ -0,0 +1,296 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+	formatTOML = "toml"
+)
+
+var (
+	tomlKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9_."'-]+\s*=`)
+	yamlErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	tomlTablePattern = regexp.MustCompile(`^\[\[?[A-Za-z0-9_."' -]+\]\]?$`)
+)
+
+// detectFormat picks the config format from the file extension and falls
+// back to sniffing the first meaningful line of the content.
+func detectFormat(path string, raw []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return formatJSON
+	case ".yaml", ".yml":
+		return formatYAML
+	case ".toml":
+		return formatTOML
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "{"):
+			return formatJSON
+		case tomlTablePattern.MatchString(line), tomlKeyPattern.MatchString(line):
+			return formatTOML
+		default:
+			return formatYAML
+		}
+	}
+
+	return formatJSON
+}
+
+// decodeConfig decodes raw into a DashboardConfig. YAML and TOML documents
+// are converted to JSON first so every format shares the json struct tags,
+// the JSON document is returned alongside the config.
+// Errors are prefixed with `file:line:col` whenever the position is known.
+func decodeConfig(path string, raw []byte) (*DashboardConfig, []byte, error) {
+	var cfg *DashboardConfig
+	var doc []byte
+
+	switch detectFormat(path, raw) {
+	case formatYAML:
+		var node yaml.Node
+		if err := yaml.Unmarshal(raw, &node); err != nil {
+			return nil, nil, yamlError(path, err)
+		}
+
+		enc := &yamlEncoder{}
+		if err := enc.encode(&node); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		doc = enc.buf.Bytes()
+		if err := json.Unmarshal(doc, &cfg); err != nil {
+			return nil, nil, enc.positionError(path, err)
+		}
+
+	case formatTOML:
+		var table map[string]any
+		if _, err := toml.Decode(string(raw), &table); err != nil {
+			var parseErr toml.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, nil, fmt.Errorf("%s:%d:%d: %s", path, parseErr.Position.Line, parseErr.Position.Col, parseErr.Message)
+			}
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		enc := &tomlEncoder{}
+		if err := enc.encode(table, ""); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		doc = enc.buf.Bytes()
+		if err := json.Unmarshal(doc, &cfg); err != nil {
+			return nil, nil, enc.positionError(path, raw, err)
+		}
+
+	default:
+		doc = raw
+		if err := json.Unmarshal(doc, &cfg); err != nil {
+			return nil, nil, jsonError(path, raw, err)
+		}
+	}
+
+	if cfg == nil {
+		return nil, nil, fmt.Errorf("%s: config is empty", path)
+	}
+
+	return cfg, doc, nil
+}
+
+func jsonError(path string, raw []byte, err error) error {
+	var offset int64 = -1
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	}
+
+	if offset < 0 {
+		return fmt.Errorf("%s: %s", path, describeJSONError(err))
+	}
+
+	line, col := lineCol(raw, int(offset))
+	return fmt.Errorf("%s:%d:%d: %s", path, line, col, describeJSONError(err))
+}
+
+func yamlError(path string, err error) error {
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("%s: %s", path, strings.Join(typeErr.Errors, "; "))
+	}
+
+	if match := yamlErrorPattern.FindStringSubmatch(err.Error()); match != nil {
+		return fmt.Errorf("%s:%s: %s", path, match[1], match[2])
+	}
+
+	return fmt.Errorf("%s: %w", path, err)
+}
+
+func describeJSONError(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return fmt.Sprintf("cannot use %s as %s for %s", typeErr.Value, typeErr.Type, typeErr.Field)
+	}
+	return strings.TrimPrefix(err.Error(), "json: ")
+}
+
+func lineCol(raw []byte, offset int) (line, col int) {
+	offset = min(offset, len(raw))
+	line = 1 + bytes.Count(raw[:offset], []byte("\n"))
+	col = offset - bytes.LastIndexByte(raw[:offset], '\n')
+	return line, col
+}
+
+// yamlEncoder writes a YAML node tree as JSON while remembering where every
+// value starts, so decoding errors can be mapped back to YAML lines.
+type yamlEncoder struct {
+	buf     bytes.Buffer
+	offsets []int
+	nodes   []*yaml.Node
+}
+
+func (e *yamlEncoder) encode(node *yaml.Node) error {
+	e.offsets = append(e.offsets, e.buf.Len())
+	e.nodes = append(e.nodes, node)
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(node.Content[0])
+
+	case yaml.AliasNode:
+		return e.encode(node.Alias)
+
+	case yaml.SequenceNode:
+		e.buf.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			if err := e.encode(child); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte(']')
+		return nil
+
+	case yaml.MappingNode:
+		e.buf.WriteByte('{')
+		for i, pair := range mappingPairs(node) {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(pair[0].Value)
+			e.buf.Write(key)
+			e.buf.WriteByte(':')
+			if err := e.encode(pair[1]); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte('}')
+		return nil
+
+	default:
+		var value any
+		if err := node.Decode(&value); err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		e.buf.Write(encoded)
+		return nil
+	}
+}
+
+// mappingPairs returns key/value pairs of a mapping with `<<` merge keys
+// resolved. Keys defined on the mapping itself win over merged ones.
+func mappingPairs(node *yaml.Node) [][2]*yaml.Node {
+	var pairs [][2]*yaml.Node
+	seen := make(map[string]int)
+
+	add := func(key, value *yaml.Node, override bool) {
+		if idx, ok := seen[key.Value]; ok {
+			if override {
+				pairs[idx][1] = value
+			}
+			return
+		}
+		seen[key.Value] = len(pairs)
+		pairs = append(pairs, [2]*yaml.Node{key, value})
+	}
+
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "<<" && key.Tag == "!!merge" {
+			merged = append(merged, value)
+			continue
+		}
+		add(key, value, true)
+	}
+
+	for _, value := range merged {
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, src := range sources {
+			if src.Kind == yaml.AliasNode {
+				src = src.Alias
+			}
+			for i := 0; i+1 < len(src.Content); i += 2 {
+				add(src.Content[i], src.Content[i+1], false)
+			}
+		}
+	}
+
+	return pairs
+}
+
+func (e *yamlEncoder) positionError(path string, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return fmt.Errorf("%s: %s", path, describeJSONError(err))
+	}
+
+	// The error offset points past the offending value, so the last value
+	// starting before it is the one that failed to decode.
+	idx := sort.SearchInts(e.offsets, int(typeErr.Offset)) - 1
+	if idx < 0 {
+		return fmt.Errorf("%s: %s", path, describeJSONError(err))
+	}
+
+	node := e.nodes[idx]
+	return fmt.Errorf("%s:%d:%d: %s", path, node.Line, node.Column, describeJSONError(err))
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecodeConfigErrorPositions(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		doc  string
+		want string
+	}{
+		{
+			name: "json",
+			path: "c.json",
+			doc:  "{\n  \"layout\": {\n    \"flex\": \"one\"\n  }\n}",
+			want: "c.json:3:18: cannot use string as int",
+		},
+		{
+			name: "yaml",
+			path: "c.yaml",
+			doc:  "layout:\n  type: container\n  flex: one\n",
+			want: "c.yaml:3:9: cannot use string as int",
+		},
+		{
+			name: "toml table key",
+			path: "c.toml",
+			doc:  "[layout]\ntype = \"container\"\nflex = \"one\"\n",
+			want: "c.toml:3:1: cannot use string as int",
+		},
+		{
+			name: "toml array of tables",
+			path: "c.toml",
+			doc: "[layout]\ntype = \"container\"\n\n" +
+				"[[layout.children]]\ntype = \"component\"\n\n" +
+				"[[layout.children]]\ntype = \"component\"\n  [layout.children.component]\n  title = 5\n",
+			want: "c.toml:10:3: cannot use number as string",
+		},
+		{
+			name: "toml inline table",
+			path: "c.toml",
+			doc:  "[layout]\ntype = \"container\"\nchildren = [\n  { type = \"component\", flex = \"2\" },\n]\n",
+			want: "c.toml:3:1: cannot use string as int",
+		},
+		{
+			name: "toml dotted key",
+			path: "c.toml",
+			doc:  "title = \"\"\"\nflex = 1\n\"\"\"\nlayout.type = \"container\"\nlayout.flex = true\n",
+			want: "c.toml:5:1: cannot use bool as int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := decodeConfig(tt.path, []byte(tt.doc))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("error = %q, want prefix %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTOMLKey(t *testing.T) {
+	tests := map[string][]string{
+		"layout":                {"layout"},
+		"layout.children":       {"layout", "children"},
+		` a . "b.c" . 'd' `:     {"a", "b.c", "d"},
+		`"quoted key".plain`:    {"quoted key", "plain"},
+		"":                      nil,
+		`headers."X-Api-Token"`: {"headers", "X-Api-Token"},
+	}
+
+	for key, want := range tests {
+		got := splitTOMLKey(key)
+		if strings.Join(got, "|") != strings.Join(want, "|") || len(got) != len(want) {
+			t.Errorf("splitTOMLKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
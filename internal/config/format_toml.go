@@ -0,0 +1,237 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var tomlHeaderPattern = regexp.MustCompile(`^(\[\[?)\s*(.+?)\s*\]\]?$`)
+
+type tomlPosition struct {
+	line, col int
+}
+
+// tomlPositions finds where tables and keys are defined in a TOML document.
+// Paths are dotted like the JSON of the document, with [i] for entries of
+// arrays of tables. Keys inside inline tables and arrays are not tracked,
+// errors in them are reported at the closest enclosing key.
+func tomlPositions(raw []byte) map[string]tomlPosition {
+	positions := make(map[string]tomlPosition)
+	arrays := make(map[string]int)
+	table := ""
+	multiline := ""
+
+	// resolve turns dotted key segments into a path, picking the latest
+	// entry of every array of tables on the way.
+	resolve := func(base string, segments []string) string {
+		path := base
+		for _, seg := range segments {
+			path = joinPath(path, seg)
+			if idx, ok := arrays[path]; ok {
+				path = fmt.Sprintf("%s[%d]", path, idx)
+			}
+		}
+		return path
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		text := scanner.Text()
+		trimmed := strings.TrimSpace(text)
+		col := len(text) - len(strings.TrimLeft(text, " \t")) + 1
+
+		if multiline != "" {
+			if strings.Count(trimmed, multiline)%2 == 1 {
+				multiline = ""
+			}
+			continue
+		}
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if match := tomlHeaderPattern.FindStringSubmatch(trimmed); match != nil {
+			segments := splitTOMLKey(match[2])
+			if len(segments) == 0 {
+				continue
+			}
+
+			if match[1] == "[[" {
+				parent := resolve("", segments[:len(segments)-1])
+				full := joinPath(parent, segments[len(segments)-1])
+				idx, seen := arrays[full]
+				if seen {
+					idx++
+				} else if _, ok := positions[full]; !ok {
+					positions[full] = tomlPosition{lineNo, col}
+				}
+				arrays[full] = idx
+				table = fmt.Sprintf("%s[%d]", full, idx)
+			} else {
+				table = resolve("", segments)
+			}
+			positions[table] = tomlPosition{lineNo, col}
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			continue
+		}
+		if segments := splitTOMLKey(key); len(segments) > 0 {
+			positions[resolve(table, segments)] = tomlPosition{lineNo, col}
+		}
+
+		for _, quote := range []string{`"""`, `'''`} {
+			if strings.Count(value, quote)%2 == 1 {
+				multiline = quote
+			}
+		}
+	}
+
+	return positions
+}
+
+// splitTOMLKey splits a dotted key, dots inside quotes are part of the key.
+func splitTOMLKey(key string) []string {
+	var segments []string
+	var current strings.Builder
+	var quote rune
+
+	flush := func() {
+		if seg := strings.TrimSpace(current.String()); seg != "" {
+			segments = append(segments, strings.Trim(seg, `"'`))
+		}
+		current.Reset()
+	}
+
+	for _, r := range key {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			current.WriteRune(r)
+		case r == '.':
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return segments
+}
+
+// tomlEncoder writes a decoded TOML document as JSON while remembering the
+// path of every value, so decoding errors can be mapped back to TOML lines.
+type tomlEncoder struct {
+	buf     bytes.Buffer
+	offsets []int
+	paths   []string
+}
+
+func (e *tomlEncoder) encode(value any, path string) error {
+	e.offsets = append(e.offsets, e.buf.Len())
+	e.paths = append(e.paths, path)
+
+	switch v := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		e.buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(k)
+			e.buf.Write(key)
+			e.buf.WriteByte(':')
+			if err := e.encode(v[k], joinPath(path, k)); err != nil {
+				return err
+			}
+		}
+		e.buf.WriteByte('}')
+		return nil
+
+	case []map[string]any:
+		items := make([]any, len(v))
+		for i, item := range v {
+			items[i] = item
+		}
+		return e.encodeArray(items, path)
+
+	case []any:
+		return e.encodeArray(v, path)
+
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		e.buf.Write(encoded)
+		return nil
+	}
+}
+
+func (e *tomlEncoder) encodeArray(items []any, path string) error {
+	e.buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+// positionError points a JSON decoding error at the TOML key of the value
+// that failed, or at the closest enclosing key that has a known position.
+func (e *tomlEncoder) positionError(path string, raw []byte, err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return fmt.Errorf("%s: %s", path, describeJSONError(err))
+	}
+
+	idx := sort.SearchInts(e.offsets, int(typeErr.Offset)) - 1
+	if idx < 0 {
+		return fmt.Errorf("%s: %s", path, describeJSONError(err))
+	}
+
+	positions := tomlPositions(raw)
+	for key := e.paths[idx]; key != ""; key = parentPath(key) {
+		if pos, ok := positions[key]; ok {
+			return fmt.Errorf("%s:%d:%d: %s", path, pos.line, pos.col, describeJSONError(err))
+		}
+	}
+	return fmt.Errorf("%s: %s", path, describeJSONError(err))
+}
+
+// parentPath drops the last key or index of path.
+func parentPath(path string) string {
+	if strings.HasSuffix(path, "]") {
+		if i := strings.LastIndexByte(path, '['); i >= 0 {
+			return path[:i]
+		}
+	}
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
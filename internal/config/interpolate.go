@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// varPattern matches ${NAME} and ${NAME:-default}. A leading `$$` escapes
+// the expression so `$${NAME}` is kept as a literal `${NAME}`.
+var varPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+type interpolator struct {
+	raw       map[string]string
+	vars      map[string]string
+	resolving map[string]bool
+	problems  []string
+}
+
+// interpolate expands variable references in every string of the config.
+// Environment variables take precedence over the `variables` block, so the
+// block acts as a set of per-file defaults. Values in the block can
+// reference other variables, as long as they don't form a cycle.
+func interpolate(cfg *DashboardConfig) error {
+	in := &interpolator{
+		raw:       cfg.Variables,
+		vars:      make(map[string]string),
+		resolving: make(map[string]bool),
+	}
+
+	for _, name := range sortedKeys(cfg.Variables) {
+		in.resolve(name, "variables."+name)
+	}
+
+	variables := cfg.Variables
+	cfg.Variables = nil
+	in.walk(reflect.ValueOf(cfg).Elem(), "")
+	cfg.Variables = variables
+
+	if len(in.problems) > 0 {
+		return fmt.Errorf("invalid variables:\n  %s", strings.Join(in.problems, "\n  "))
+	}
+
+	return nil
+}
+
+func (in *interpolator) lookup(name, path string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	return in.resolve(name, path)
+}
+
+// resolve returns the expanded value of the variable name from the
+// `variables` block, expanding the variables it references first. path is
+// where the reference was found, it is reported when name is part of a
+// cycle.
+func (in *interpolator) resolve(name, path string) (string, bool) {
+	if value, ok := in.vars[name]; ok {
+		return value, true
+	}
+
+	raw, ok := in.raw[name]
+	if !ok {
+		return "", false
+	}
+
+	if in.resolving[name] {
+		in.problems = append(in.problems, fmt.Sprintf("%s: ${%s} is part of a reference cycle", path, name))
+		return "", true
+	}
+
+	in.resolving[name] = true
+	value := in.expand(raw, "variables."+name)
+	delete(in.resolving, name)
+
+	in.vars[name] = value
+	return value, true
+}
+
+func (in *interpolator) expand(s, path string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	return varPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if strings.HasPrefix(match, "$$") {
+			return match[1:]
+		}
+
+		groups := varPattern.FindStringSubmatch(match)
+		name, hasDefault, fallback := groups[1], groups[2] != "", groups[3]
+
+		value, ok := in.lookup(name, path)
+		if ok && (value != "" || !hasDefault) {
+			return value
+		}
+		if hasDefault {
+			return fallback
+		}
+
+		in.problems = append(in.problems, fmt.Sprintf("%s: ${%s} is not defined", path, name))
+		return ""
+	})
+}
+
+func (in *interpolator) walk(v reflect.Value, path string) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if !v.IsNil() {
+			in.walk(v.Elem(), path)
+		}
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			if t.Field(i).IsExported() {
+				in.walk(v.Field(i), joinPath(path, fieldName(t.Field(i))))
+			}
+		}
+
+	case reflect.Slice:
+		for i := range v.Len() {
+			in.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			elemPath := joinPath(path, fmt.Sprint(iter.Key().Interface()))
+			elem := reflect.New(iter.Value().Type()).Elem()
+			elem.Set(iter.Value())
+			in.walk(elem, elemPath)
+			v.SetMapIndex(iter.Key(), elem)
+		}
+
+	case reflect.Interface:
+		if !v.IsNil() {
+			elem := reflect.New(v.Elem().Type()).Elem()
+			elem.Set(v.Elem())
+			in.walk(elem, path)
+			v.Set(elem)
+		}
+
+	case reflect.String:
+		v.SetString(in.expand(v.String(), path))
+	}
+}
+
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func textConfig(title string, vars map[string]string) *DashboardConfig {
+	return &DashboardConfig{
+		Variables: vars,
+		Layout: &LayoutNode{
+			Type: "component",
+			Component: &Component{
+				Type:  "text",
+				Title: title,
+				Data:  &DataConfig{Source: "script", Command: "echo"},
+			},
+		},
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Setenv("DASHBREW_TEST_HOST", "example.com")
+
+	tests := []struct {
+		name  string
+		title string
+		vars  map[string]string
+		want  string
+	}{
+		{"plain text", "no variables", nil, "no variables"},
+		{"variable", "Weather in ${city}", map[string]string{"city": "Yerevan"}, "Weather in Yerevan"},
+		{"env", "${DASHBREW_TEST_HOST}", nil, "example.com"},
+		{"env before variables", "${DASHBREW_TEST_HOST}", map[string]string{"DASHBREW_TEST_HOST": "local"}, "example.com"},
+		{"default", "${missing:-fallback}", nil, "fallback"},
+		{"default for empty value", "${empty:-fallback}", map[string]string{"empty": ""}, "fallback"},
+		{"empty default", "[${missing:-}]", nil, "[]"},
+		{"escape", "$${city} ${city}", map[string]string{"city": "Yerevan"}, "${city} Yerevan"},
+		{"variable references variable", "${url}", map[string]string{"url": "https://${host}/api", "host": "${DASHBREW_TEST_HOST}"}, "https://example.com/api"},
+		{"chain", "${a}", map[string]string{"a": "${b}!", "b": "${c}?", "c": "end"}, "end?!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := textConfig(tt.title, tt.vars)
+			if err := interpolate(cfg); err != nil {
+				t.Fatalf("interpolate: %v", err)
+			}
+			if got := cfg.Layout.Component.Title; got != tt.want {
+				t.Errorf("title = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInterpolateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		title string
+		vars  map[string]string
+		want  []string
+	}{
+		{
+			name:  "undefined",
+			title: "${nope}",
+			want:  []string{"layout.component.title: ${nope} is not defined"},
+		},
+		{
+			name:  "undefined in variable",
+			title: "${a}",
+			vars:  map[string]string{"a": "${nope}"},
+			want:  []string{"variables.a: ${nope} is not defined"},
+		},
+		{
+			name:  "self reference",
+			title: "x",
+			vars:  map[string]string{"a": "${a}"},
+			want:  []string{"variables.a: ${a} is part of a reference cycle"},
+		},
+		{
+			name:  "cycle",
+			title: "${a}",
+			vars:  map[string]string{"a": "${b}", "b": "${a}"},
+			want:  []string{"variables.b: ${a} is part of a reference cycle"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := interpolate(textConfig(tt.title, tt.vars))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInterpolateNested(t *testing.T) {
+	cfg := textConfig("t", map[string]string{"token": "secret", "id": "42"})
+	cfg.Layout.Component.Data = &DataConfig{
+		Source:  "api",
+		URL:     "https://api/${id}",
+		Headers: map[string]string{"Authorization": "Bearer ${token}"},
+		Body: map[string]any{
+			"id":     "${id}",
+			"count":  3.0,
+			"nested": map[string]any{"list": []any{"${token}", "$${id}"}},
+		},
+	}
+
+	if err := interpolate(cfg); err != nil {
+		t.Fatalf("interpolate: %v", err)
+	}
+
+	data := cfg.Layout.Component.Data
+	if data.URL != "https://api/42" {
+		t.Errorf("url = %q", data.URL)
+	}
+	if got := data.Headers["Authorization"]; got != "Bearer secret" {
+		t.Errorf("header = %q", got)
+	}
+
+	wantBody := map[string]any{
+		"id":     "42",
+		"count":  3.0,
+		"nested": map[string]any{"list": []any{"secret", "${id}"}},
+	}
+	if !reflect.DeepEqual(data.Body, wantBody) {
+		t.Errorf("body = %#v, want %#v", data.Body, wantBody)
+	}
+
+	if got := cfg.Variables["token"]; got != "secret" {
+		t.Errorf("variables block changed: %q", got)
+	}
+}
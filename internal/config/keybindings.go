@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const GlobalKeyScope = "global"
+
+// Keybindings maps a scope, either "global" or a component type, to its
+// actions and the keys that trigger them.
+type Keybindings map[string]map[string][]string
+
+var defaultKeybindings = Keybindings{
+	GlobalKeyScope: {
+		"up":        {"shift+up", "K"},
+		"down":      {"shift+down", "J"},
+		"left":      {"shift+left", "H"},
+		"right":     {"shift+right", "L"},
+		"add":       {"a", "A"},
+		"refresh":   {"r", "R"},
+		"zoom":      {"z", "Z"},
+		"next_page": {"tab", "]"},
+		"prev_page": {"shift+tab", "["},
+		"help":      {"?"},
+		"quit":      {"ctrl+c"},
+	},
+	"table": {
+		"up":             {"up", "k"},
+		"down":           {"down", "j"},
+		"page_up":        {"pgup", "b"},
+		"page_down":      {"pgdown", "f", " "},
+		"half_page_up":   {"u", "ctrl+u"},
+		"half_page_down": {"d", "ctrl+d"},
+		"top":            {"home", "g"},
+		"bottom":         {"end", "G"},
+		"prev_column":    {"<", ","},
+		"next_column":    {">", "."},
+		"sort":           {"s", "S"},
+		"filter":         {"/"},
+	},
+	"list": {
+		"up":        {"up", "k"},
+		"down":      {"down", "j"},
+		"page_up":   {"left", "h", "pgup", "b", "u"},
+		"page_down": {"right", "l", "pgdown", "f", "d"},
+		"top":       {"home", "g"},
+		"bottom":    {"end", "G"},
+		"filter":    {"/"},
+	},
+	"todo": {
+		"up":        {"up", "k"},
+		"down":      {"down", "j"},
+		"page_up":   {"left", "h", "pgup", "b", "u"},
+		"page_down": {"right", "l", "pgdown", "f"},
+		"top":       {"home", "g"},
+		"bottom":    {"end", "G"},
+		"filter":    {"/"},
+		"toggle":    {" "},
+		"delete":    {"delete", "d", "D"},
+	},
+	"text":      viewportKeybindings(),
+	"histogram": viewportKeybindings(),
+}
+
+// DefaultKeybindings returns a copy of the bindings used when a config
+// doesn't remap any key.
+func DefaultKeybindings() Keybindings {
+	bindings := make(Keybindings, len(defaultKeybindings))
+	for scope, actions := range defaultKeybindings {
+		bindings[scope] = maps.Clone(actions)
+	}
+	return bindings
+}
+
+// navigationActions move the cursor or scroll a component.
+var navigationActions = []string{"up", "down", "page_up", "page_down", "half_page_up", "half_page_down", "top", "bottom"}
+
+// viewportKeybindings are the scroll keys of text and histogram components.
+func viewportKeybindings() map[string][]string {
+	return map[string][]string{
+		"up":             {"up", "k"},
+		"down":           {"down", "j"},
+		"page_up":        {"pgup", "b"},
+		"page_down":      {"pgdown", "f", " "},
+		"half_page_up":   {"u", "ctrl+u"},
+		"half_page_down": {"d", "ctrl+d"},
+	}
+}
+
+// keyAliases lets configs spell out keys that are awkward to write.
+var keyAliases = map[string]string{
+	"space": " ",
+}
+
+// resolveKeybindings merges the configured bindings over the defaults. An
+// action that is configured replaces all of its default keys. Unknown scopes
+// or actions and keys bound to more than one action are reported as errors.
+func resolveKeybindings(configured Keybindings) (Keybindings, error) {
+	resolved := DefaultKeybindings()
+
+	var problems []string
+
+	for _, scope := range sortedKeys(configured) {
+		defaults, ok := defaultKeybindings[scope]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("keybindings.%s: unknown scope, expected one of %s", scope, quoteList(sortedKeys(defaultKeybindings))))
+			continue
+		}
+
+		for _, action := range sortedKeys(configured[scope]) {
+			if _, ok := defaults[action]; !ok {
+				problems = append(problems, fmt.Sprintf("keybindings.%s.%s: unknown action, expected one of %s", scope, action, quoteList(sortedKeys(defaults))))
+				continue
+			}
+
+			keys := configured[scope][action]
+			if len(keys) == 0 {
+				problems = append(problems, fmt.Sprintf("keybindings.%s.%s: no keys given", scope, action))
+				continue
+			}
+
+			normalized := make([]string, len(keys))
+			for i, k := range keys {
+				if alias, ok := keyAliases[strings.ToLower(k)]; ok {
+					k = alias
+				}
+				normalized[i] = k
+			}
+			resolved[scope][action] = normalized
+		}
+	}
+
+	problems = append(problems, keyConflicts(resolved)...)
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid keybindings:\n  %s", strings.Join(problems, "\n  "))
+	}
+
+	return resolved, nil
+}
+
+// keyConflicts reports keys bound to two actions of the same scope and
+// component keys that are shadowed by a global binding.
+func keyConflicts(bindings Keybindings) []string {
+	var problems []string
+
+	owners := func(scope string) map[string]string {
+		owner := make(map[string]string)
+		for _, action := range sortedKeys(bindings[scope]) {
+			for _, k := range bindings[scope][action] {
+				if other, taken := owner[k]; taken {
+					problems = append(problems, fmt.Sprintf("keybindings.%s: %q is bound to both %q and %q", scope, displayKey(k), other, action))
+					continue
+				}
+				owner[k] = action
+			}
+		}
+		return owner
+	}
+
+	global := owners(GlobalKeyScope)
+	for _, scope := range sortedKeys(bindings) {
+		if scope == GlobalKeyScope {
+			continue
+		}
+
+		local := owners(scope)
+		for _, k := range slices.Sorted(maps.Keys(local)) {
+			if globalAction, taken := global[k]; taken {
+				problems = append(problems, fmt.Sprintf("keybindings.%s.%s: %q is already bound to global action %q", scope, local[k], displayKey(k), globalAction))
+			}
+		}
+	}
+
+	return problems
+}
+
+func displayKey(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveKeybindings(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured Keybindings
+		want       []string
+	}{
+		{name: "defaults"},
+		{name: "component keys", configured: Keybindings{"list": {"down": {"n"}}, "text": {"page_down": {"space", "pgdown"}}}},
+		{
+			name:       "global shadows component keys",
+			configured: Keybindings{GlobalKeyScope: {"up": {"k"}}},
+			want: []string{
+				`keybindings.histogram.up: "k" is already bound to global action "up"`,
+				`keybindings.list.up: "k" is already bound to global action "up"`,
+				`keybindings.table.up: "k" is already bound to global action "up"`,
+				`keybindings.text.up: "k" is already bound to global action "up"`,
+				`keybindings.todo.up: "k" is already bound to global action "up"`,
+			},
+		},
+		{
+			name:       "global shadows remapped component keys",
+			configured: Keybindings{GlobalKeyScope: {"up": {"k"}}, "list": {"up": {"up"}}, "todo": {"up": {"up"}}, "table": {"up": {"up"}}, "text": {"up": {"up"}}},
+			want:       []string{`keybindings.histogram.up: "k" is already bound to global action "up"`},
+		},
+		{
+			name:       "same scope",
+			configured: Keybindings{"table": {"top": {"s"}}},
+			want:       []string{`keybindings.table: "s" is bound to both "sort" and "top"`},
+		},
+		{
+			name:       "unknown action",
+			configured: Keybindings{"list": {"scroll": {"x"}}},
+			want:       []string{`keybindings.list.scroll: unknown action`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := resolveKeybindings(tt.configured)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for scope, actions := range tt.configured {
+					for action, keys := range actions {
+						if got := resolved[scope][action]; len(got) != len(keys) {
+							t.Errorf("%s.%s = %q, want %d keys", scope, action, got, len(keys))
+						}
+					}
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+			if got := strings.Count(err.Error(), "\n"); got != len(tt.want) {
+				t.Errorf("got %d problems, want %d: %v", got, len(tt.want), err)
+			}
+		})
+	}
+}
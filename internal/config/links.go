@@ -0,0 +1,56 @@
+package config
+
+// Linked reports whether the command or url of d follow the selection of
+// another component.
+func (d *DataConfig) Linked() bool {
+	return d != nil && d.SelectionFrom != ""
+}
+
+// ExpandSelection returns a copy of d with the command and url templates
+// filled in with the fields of row, the selected row of the component named
+// by SelectionFrom. Templates work like the ones of actions.
+func (d *DataConfig) ExpandSelection(row map[string]any) (*DataConfig, error) {
+	expanded := *d
+
+	var err error
+	if expanded.Command, err = expandRow(d.Command, row, true); err != nil {
+		return nil, err
+	}
+	if expanded.URL, err = expandRow(d.URL, row, false); err != nil {
+		return nil, err
+	}
+	return &expanded, nil
+}
+
+type selectionLink struct {
+	path   string
+	target string
+}
+
+// validateLink checks the templates of a linked data config, the component
+// it follows is checked by validateLinks once all ids are known.
+func (v *validator) validateLink(comp *Component, data *DataConfig, path string) {
+	if data.SelectionFrom == comp.ID {
+		v.report(joinPath(path, "selection_from"), "a component cannot follow its own selection")
+		return
+	}
+	v.links = append(v.links, selectionLink{path: joinPath(path, "selection_from"), target: data.SelectionFrom})
+
+	for field, text := range map[string]string{"command": data.Command, "url": data.URL} {
+		if _, err := parseAction(text); err != nil {
+			v.report(joinPath(path, field), "invalid template: %v", err)
+		}
+	}
+}
+
+func (v *validator) validateLinks() {
+	for _, link := range v.links {
+		compType, ok := v.types[link.target]
+		switch {
+		case !ok:
+			v.report(link.path, "no component with id %q", link.target)
+		case compType != "table" && compType != "list":
+			v.report(link.path, "%q is a %s component, only table and list selections can be followed", link.target, compType)
+		}
+	}
+}
@@ -0,0 +1,592 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	layoutTypes    = []string{"container", "component"}
+	directions     = []string{"row", "column"}
+	componentTypes = []string{"text", "list", "todo", "chart", "table", "histogram", "gauge", "stat"}
+	dataSources    = []string{"script", "api", "stream"}
+	refreshModes   = []string{"replace", "append"}
+	borderTypes    = []string{"rounded", "thicc", "double", "hidden", "normal", "md", "ascii", "block"}
+	authTypes      = []string{"basic", "bearer"}
+	sortTypes      = []string{"number", "date", "string"}
+	sortOrders     = []string{"asc", "desc"}
+	tableFormats   = []string{"json", "csv", "tsv", "whitespace"}
+	columnFormats  = []string{"number", "bytes", "duration", "relative", "bool"}
+)
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Problem is a single validation finding, Path points at the offending
+// value using dotted keys and [index] for arrays.
+type Problem struct {
+	Path    string
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+type ValidationError struct {
+	Path     string
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = p.String()
+	}
+	return fmt.Sprintf("%s: %d problem(s) found:\n  %s", e.Path, len(e.Problems), strings.Join(lines, "\n  "))
+}
+
+// Validate loads the config at path and reports everything that would only
+// surface at runtime: unknown keys, unknown types and sources, duplicate IDs,
+// invalid colors and settings that have no effect for the chosen component
+// and source. A returned error means the config could not be loaded at all.
+func Validate(path string) (*DashboardConfig, []Problem, error) {
+	cfg, doc, err := load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := &validator{ids: make(map[string]string), types: make(map[string]string), history: cfg.History != nil}
+
+	var tree any
+	if err := json.Unmarshal(doc, &tree); err == nil {
+		v.checkKeys(tree, reflect.TypeOf(cfg), "")
+	}
+
+	v.validate(cfg)
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Path < v.problems[j].Path
+	})
+
+	return cfg, v.problems, nil
+}
+
+type validator struct {
+	problems []Problem
+	ids      map[string]string
+	types    map[string]string
+	links    []selectionLink
+	history  bool
+}
+
+func (v *validator) report(path, format string, args ...any) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// checkKeys walks the raw document next to the config types and reports keys
+// that do not map to any field.
+func (v *validator) checkKeys(node any, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := node.(map[string]any)
+		if !ok {
+			return
+		}
+
+		fields := make(map[string]reflect.Type)
+		for i := range t.NumField() {
+			if t.Field(i).IsExported() {
+				fields[fieldName(t.Field(i))] = t.Field(i).Type
+			}
+		}
+
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			fieldType, known := fields[k]
+			if !known {
+				if suggestion := closestField(k, fields); suggestion != "" {
+					v.report(joinPath(path, k), "unknown key, did you mean %q?", suggestion)
+				} else {
+					v.report(joinPath(path, k), "unknown key")
+				}
+				continue
+			}
+			v.checkKeys(obj[k], fieldType, joinPath(path, k))
+		}
+
+	case reflect.Slice:
+		arr, ok := node.([]any)
+		if !ok {
+			return
+		}
+		for i, elem := range arr {
+			v.checkKeys(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func closestField(key string, fields map[string]reflect.Type) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+
+	for name := range fields {
+		if normalize(name) == normalize(key) {
+			return name
+		}
+	}
+	return ""
+}
+
+func (v *validator) validate(cfg *DashboardConfig) {
+	switch {
+	case cfg.Layout != nil && len(cfg.Pages) > 0:
+		v.report("pages", "cannot be combined with a top level layout")
+	case cfg.Layout == nil && len(cfg.Pages) == 0:
+		v.report("layout", "missing layout or pages")
+	}
+
+	if cfg.Layout != nil {
+		v.validateNode(cfg.Layout, "layout")
+	}
+
+	for i, page := range cfg.Pages {
+		path := fmt.Sprintf("pages[%d]", i)
+		switch {
+		case page == nil:
+			v.report(path, "empty page")
+		case page.Layout == nil:
+			v.report(joinPath(path, "layout"), "missing layout")
+		default:
+			v.validateNode(page.Layout, joinPath(path, "layout"))
+		}
+	}
+
+	v.validateLinks()
+	v.validateStyle(cfg.Style, "style")
+
+	if h := cfg.History; h != nil {
+		if h.RetentionHours < 0 {
+			v.report("history.retention_hours", "must not be negative")
+		}
+		if h.MaxRecords < 0 {
+			v.report("history.max_records", "must not be negative")
+		}
+	}
+}
+
+func (v *validator) validateNode(node *LayoutNode, path string) {
+	if !slices.Contains(layoutTypes, node.Type) {
+		v.report(joinPath(path, "type"), "unknown layout type %q, expected one of %s", node.Type, quoteList(layoutTypes))
+	}
+
+	if node.Flex < 0 {
+		v.report(joinPath(path, "flex"), "must not be negative")
+	}
+
+	if node.Direction != "" && !slices.Contains(directions, node.Direction) {
+		v.report(joinPath(path, "direction"), "unknown direction %q, expected one of %s", node.Direction, quoteList(directions))
+	}
+
+	switch node.Type {
+	case "container":
+		if len(node.Children) == 0 {
+			v.report(joinPath(path, "children"), "container has no children")
+		}
+		if node.Component != nil {
+			v.report(joinPath(path, "component"), "ignored on container nodes")
+		}
+	case "component":
+		if node.Component == nil {
+			v.report(joinPath(path, "component"), "missing component")
+		} else {
+			v.validateComponent(node.Component, joinPath(path, "component"))
+		}
+		if len(node.Children) > 0 {
+			v.report(joinPath(path, "children"), "ignored on component nodes")
+		}
+	}
+
+	for i, child := range node.Children {
+		if child == nil {
+			v.report(fmt.Sprintf("%s.children[%d]", path, i), "empty layout node")
+			continue
+		}
+		v.validateNode(child, fmt.Sprintf("%s.children[%d]", path, i))
+	}
+}
+
+func (v *validator) validateComponent(comp *Component, path string) {
+	if comp.ID != "" {
+		if firstPath, exists := v.ids[comp.ID]; exists {
+			v.report(joinPath(path, "id"), "duplicate id %q, already used at %s", comp.ID, firstPath)
+		} else {
+			v.ids[comp.ID] = joinPath(path, "id")
+			v.types[comp.ID] = comp.Type
+		}
+	}
+
+	if !slices.Contains(componentTypes, comp.Type) {
+		v.report(joinPath(path, "type"), "unknown component type %q, expected one of %s", comp.Type, quoteList(componentTypes))
+	}
+
+	v.validateActions(comp, path)
+	v.validateAlerts(comp, path)
+
+	if comp.Data == nil {
+		v.report(joinPath(path, "data"), "missing data config")
+		return
+	}
+
+	if v.history && comp.ID == "" && comp.usesHistory() {
+		v.report(joinPath(path, "id"), "history is only recorded for components with an id")
+	}
+
+	v.validateData(comp, comp.Data, joinPath(path, "data"))
+}
+
+func (v *validator) validateData(comp *Component, data *DataConfig, path string) {
+	source := data.Source
+
+	if comp.Type == "todo" {
+		if source == "" || slices.Contains(dataSources, source) {
+			v.report(joinPath(path, "source"), "todo components read a file, set source to the path of a todo file")
+		}
+		if data.Linked() {
+			v.report(joinPath(path, "selection_from"), "not used by todo components")
+		}
+		return
+	}
+
+	if data.Linked() {
+		v.validateLink(comp, data, path)
+	}
+
+	if !slices.Contains(dataSources, source) {
+		v.report(joinPath(path, "source"), "unknown data source %q, expected one of %s", source, quoteList(dataSources))
+	}
+
+	switch source {
+	case "script", "stream":
+		if data.Command == "" {
+			v.report(joinPath(path, "command"), "%s sources require a command", source)
+		}
+		if data.URL != "" {
+			v.report(joinPath(path, "url"), "only used by api sources")
+		}
+	case "api":
+		if data.URL == "" {
+			v.report(joinPath(path, "url"), "api sources require a url")
+		}
+		if data.Command != "" {
+			v.report(joinPath(path, "command"), "only used by script and stream sources")
+		}
+	}
+
+	if source != "api" {
+		apiOnly := map[string]bool{
+			"method":  data.Method != "",
+			"headers": len(data.Headers) > 0,
+			"query":   len(data.Query) > 0,
+			"body":    data.Body != nil,
+			"auth":    data.Auth != nil,
+			"tls":     data.TLS != nil,
+		}
+		for _, key := range sortedKeys(apiOnly) {
+			if apiOnly[key] {
+				v.report(joinPath(path, key), "only used by api sources")
+			}
+		}
+	}
+
+	if source == "script" && data.JSONPath != "" {
+		v.report(joinPath(path, "json_path"), "not applied to script output")
+	}
+
+	if source == "stream" {
+		if data.RefreshInterval > 0 {
+			v.report(joinPath(path, "refresh_interval"), "ignored for stream sources, use restart_delay")
+		}
+	} else {
+		if data.RestartDelay != 0 {
+			v.report(joinPath(path, "restart_delay"), "only used by stream sources")
+		}
+		if data.StreamBuffer != 0 {
+			v.report(joinPath(path, "stream_buffer"), "only used by stream sources")
+		}
+	}
+
+	if data.RefreshMode != "" {
+		if !slices.Contains(refreshModes, data.RefreshMode) {
+			v.report(joinPath(path, "refresh_mode"), "unknown refresh mode %q, expected one of %s", data.RefreshMode, quoteList(refreshModes))
+		} else if data.RefreshMode == "append" && comp.Type != "chart" {
+			v.report(joinPath(path, "refresh_mode"), "append mode only applies to chart components")
+		}
+	}
+
+	if comp.Type == "table" {
+		v.validateTableFormat(data, path)
+	} else {
+		tableOnly := map[string]bool{
+			"columns":   len(data.Columns) > 0,
+			"format":    data.Format != "",
+			"delimiter": data.Delimiter != "",
+			"header":    data.Header,
+		}
+		for _, key := range sortedKeys(tableOnly) {
+			if tableOnly[key] {
+				v.report(joinPath(path, key), "only used by table components")
+			}
+		}
+	}
+
+	for i, col := range data.Columns {
+		if col == nil {
+			continue
+		}
+		if col.Flex < 0 {
+			v.report(fmt.Sprintf("%s.columns[%d].flex", path, i), "must not be negative")
+		}
+		if col.Sort != "" && !slices.Contains(sortTypes, col.Sort) {
+			v.report(fmt.Sprintf("%s.columns[%d].sort", path, i), "unknown sort type %q, expected one of %s", col.Sort, quoteList(sortTypes))
+		}
+		v.validateColumnFormat(col, fmt.Sprintf("%s.columns[%d]", path, i))
+		for j, rule := range col.Rules {
+			v.validateRule(rule, false, data.Columns, fmt.Sprintf("%s.columns[%d].rules[%d]", path, i, j))
+		}
+	}
+
+	for i, rule := range data.Rules {
+		if comp.Type != "table" {
+			v.report(joinPath(path, "rules"), "only used by table components")
+			break
+		}
+		v.validateRule(rule, true, data.Columns, fmt.Sprintf("%s.rules[%d]", path, i))
+	}
+
+	if data.Sort != nil {
+		v.validateSort(joinPath(path, "sort"), comp.Type, data)
+	}
+
+	if comp.Type != "gauge" {
+		gaugeOnly := map[string]bool{
+			"min": data.Min != nil,
+			"max": data.Max != nil,
+		}
+		for _, key := range sortedKeys(gaugeOnly) {
+			if gaugeOnly[key] {
+				v.report(joinPath(path, key), "only used by gauge components")
+			}
+		}
+	}
+
+	if comp.Type != "gauge" && comp.Type != "stat" {
+		valueOnly := map[string]bool{
+			"unit":       data.Unit != "",
+			"thresholds": data.Thresholds != nil,
+		}
+		for _, key := range sortedKeys(valueOnly) {
+			if valueOnly[key] {
+				v.report(joinPath(path, key), "only used by gauge and stat components")
+			}
+		}
+	}
+
+	appendChart := comp.Type == "chart" && data.RefreshMode == "append"
+	if data.MaxPoints != 0 && !appendChart && comp.Type != "stat" {
+		v.report(joinPath(path, "max_points"), "only used by append mode charts and stat components")
+	}
+	if data.WindowSeconds != 0 && !appendChart {
+		v.report(joinPath(path, "window_seconds"), "only used by append mode charts")
+	}
+
+	if comp.Type != "stat" && data.Sparkline {
+		v.report(joinPath(path, "sparkline"), "only used by stat components")
+	}
+
+	if data.Min != nil && data.Max != nil && *data.Min >= *data.Max {
+		v.report(joinPath(path, "max"), "must be greater than min")
+	}
+
+	if t := data.Thresholds; t != nil {
+		v.validateColor(t.OkColor, joinPath(path, "thresholds.ok_color"))
+		v.validateColor(t.WarnColor, joinPath(path, "thresholds.warn_color"))
+		v.validateColor(t.CritColor, joinPath(path, "thresholds.crit_color"))
+		if t.Warn == nil && t.Crit == nil {
+			v.report(joinPath(path, "thresholds"), "set at least one of warn or crit")
+		}
+	}
+
+	if data.Auth != nil && !slices.Contains(authTypes, data.Auth.Type) {
+		v.report(joinPath(path, "auth.type"), "unknown auth type %q, expected one of %s", data.Auth.Type, quoteList(authTypes))
+	}
+
+	nonNegative := map[string]int{
+		"refresh_interval": data.RefreshInterval,
+		"timeout":          data.Timeout,
+		"restart_delay":    data.RestartDelay,
+		"stream_buffer":    data.StreamBuffer,
+		"max_points":       data.MaxPoints,
+		"window_seconds":   data.WindowSeconds,
+	}
+	for _, key := range sortedKeys(nonNegative) {
+		if nonNegative[key] < 0 {
+			v.report(joinPath(path, key), "must not be negative")
+		}
+	}
+}
+
+func (v *validator) validateStyle(style *StyleConfig, path string) {
+	if style.Global != nil {
+		v.validateColor(style.Global.TextColor, joinPath(path, "global.textColor"))
+		v.validateColor(style.Global.HighlightedColor, joinPath(path, "global.highlightedColor"))
+	}
+
+	if style.Border != nil {
+		if style.Border.Type != "" && !slices.Contains(borderTypes, style.Border.Type) {
+			v.report(joinPath(path, "border.type"), "unknown border type %q, expected one of %s", style.Border.Type, quoteList(borderTypes))
+		}
+		v.validateColor(style.Border.Color, joinPath(path, "border.color"))
+		v.validateColor(style.Border.FocusedColor, joinPath(path, "border.focusedColor"))
+	}
+}
+
+func (v *validator) validateColor(color, path string) {
+	if color != "" && !hexColorPattern.MatchString(color) {
+		v.report(path, "invalid color %q, expected #rrggbb", color)
+	}
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (v *validator) validateSort(path, componentType string, data *DataConfig) {
+	if componentType != "table" {
+		v.report(path, "only used by table components")
+		return
+	}
+
+	if data.Sort.Order != "" && !slices.Contains(sortOrders, data.Sort.Order) {
+		v.report(joinPath(path, "order"), "unknown order %q, expected one of %s", data.Sort.Order, quoteList(sortOrders))
+	}
+
+	if data.Sort.Column == "" {
+		v.report(joinPath(path, "column"), "missing sort column")
+		return
+	}
+	if len(data.Columns) == 0 {
+		// Columns come from the header row and aren't known yet.
+		return
+	}
+	for _, col := range data.Columns {
+		if col != nil && col.Matches(data.Sort.Column) {
+			return
+		}
+	}
+	v.report(joinPath(path, "column"), "no column with label or field %q", data.Sort.Column)
+}
+
+func (v *validator) validateTableFormat(data *DataConfig, path string) {
+	if len(data.Columns) == 0 && !data.Header {
+		v.report(joinPath(path, "columns"), "table components require column definitions or a header row")
+	}
+
+	if data.Format != "" && !slices.Contains(tableFormats, data.Format) {
+		v.report(joinPath(path, "format"), "unknown format %q, expected one of %s", data.Format, quoteList(tableFormats))
+	}
+
+	if data.Delimiter != "" {
+		if utf8.RuneCountInString(data.Delimiter) != 1 {
+			v.report(joinPath(path, "delimiter"), "must be a single character")
+		}
+		if data.Format != "" && data.Format != "csv" {
+			v.report(joinPath(path, "delimiter"), "only used by the csv format")
+		}
+	}
+
+	if data.Header && data.Format == "json" {
+		v.report(joinPath(path, "header"), "not used by the json format")
+	}
+}
+
+func (v *validator) validateColumnFormat(col *ColumnConfig, path string) {
+	if col.Format != "" && !slices.Contains(columnFormats, col.Format) {
+		v.report(joinPath(path, "format"), "unknown format %q, expected one of %s", col.Format, quoteList(columnFormats))
+	}
+
+	if col.Precision != nil {
+		if *col.Precision < 0 {
+			v.report(joinPath(path, "precision"), "must not be negative")
+		}
+		if col.Format != "number" && col.Format != "bytes" {
+			v.report(joinPath(path, "precision"), "only used by the number and bytes formats")
+		}
+	}
+
+	if col.Truncate < 0 {
+		v.report(joinPath(path, "truncate"), "must not be negative")
+	}
+}
+
+func (v *validator) validateRule(rule *StyleRule, row bool, columns []*ColumnConfig, path string) {
+	if rule == nil {
+		return
+	}
+
+	if rule.Equals == nil && rule.Matches == "" && rule.Above == nil && rule.Below == nil {
+		v.report(path, "needs at least one of equals, matches, above or below")
+	}
+	if rule.Matches != "" {
+		if _, err := regexp.Compile(rule.Matches); err != nil {
+			v.report(joinPath(path, "matches"), "invalid regular expression: %v", err)
+		}
+	}
+	if rule.Color == "" && rule.Background == "" && !rule.Bold && !rule.Faint {
+		v.report(path, "sets no style, expected color, background, bold or faint")
+	}
+	v.validateColor(rule.Color, joinPath(path, "color"))
+	v.validateColor(rule.Background, joinPath(path, "background"))
+
+	switch {
+	case !row && rule.Column != "":
+		v.report(joinPath(path, "column"), "only used by row rules, column rules test their own column")
+	case row && rule.Column == "":
+		v.report(joinPath(path, "column"), "missing rule column")
+	case row && len(columns) > 0 && !slices.ContainsFunc(columns, func(col *ColumnConfig) bool {
+		return col != nil && col.Matches(rule.Column)
+	}):
+		v.report(joinPath(path, "column"), "no column with label or field %q", rule.Column)
+	}
+}
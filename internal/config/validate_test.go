@@ -0,0 +1,168 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func validateDoc(t *testing.T, doc string) []string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dashboard.json")
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	found := make([]string, len(problems))
+	for i, p := range problems {
+		found[i] = p.String()
+	}
+	return found
+}
+
+// component wraps a component in a single component layout.
+func component(comp string) string {
+	return `{"layout": {"type": "component", "component": ` + comp + `}}`
+}
+
+func TestValidateValid(t *testing.T) {
+	docs := map[string]string{
+		"text":  component(`{"type": "text", "title": "T", "data": {"source": "script", "command": "echo hi", "refresh_interval": 5}}`),
+		"api":   component(`{"type": "list", "title": "L", "data": {"source": "api", "url": "http://x", "method": "GET", "timeout": 3}}`),
+		"todo":  component(`{"type": "todo", "title": "Todo", "data": {"source": "todo.txt"}}`),
+		"gauge": component(`{"type": "gauge", "title": "G", "data": {"source": "script", "command": "echo 5", "min": 0, "max": 10, "unit": "%"}}`),
+		"table": component(`{"type": "table", "title": "T", "data": {"source": "script", "command": "ps", "header": true,
+			"sort": {"column": "CPU", "order": "desc"}, "columns": [{"label": "CPU", "field": "pcpu", "format": "number", "precision": 1}]}}`),
+		"linked": `{"layout": {"type": "container", "children": [
+			{"type": "component", "component": {"id": "svc", "type": "list", "title": "S", "data": {"source": "script", "command": "ls"}}},
+			{"type": "component", "component": {"type": "text", "title": "L", "data": {"source": "script", "command": "cat {{quote .value}}", "selection_from": "svc"}}}]}}`,
+		"alerts": component(`{"type": "stat", "title": "S", "alerts": [{"condition": "value", "above": 90, "for": 30}, {"condition": "error"}],
+			"data": {"source": "script", "command": "echo 1"}}`),
+	}
+
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			if problems := validateDoc(t, doc); len(problems) > 0 {
+				t.Errorf("unexpected problems: %q", problems)
+			}
+		})
+	}
+}
+
+func TestValidateProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "missing layout",
+			doc:  `{}`,
+			want: []string{"layout: missing layout or pages"},
+		},
+		{
+			name: "unknown keys",
+			doc:  component(`{"type": "text", "title": "T", "colour": 1, "data": {"source": "script", "command": "echo", "JSONPath": "$"}}`),
+			want: []string{
+				"layout.component.colour: unknown key",
+				`layout.component.data.JSONPath: unknown key, did you mean "json_path"?`,
+			},
+		},
+		{
+			name: "unknown types",
+			doc: `{"layout": {"type": "container", "direction": "diagonal", "children": [
+				{"type": "component", "component": {"type": "txt", "title": "T", "data": {"source": "ftp"}}}]}}`,
+			want: []string{
+				`layout.direction: unknown direction "diagonal", expected one of "row", "column"`,
+				`layout.children[0].component.type: unknown component type "txt", expected one of "text", "list", "todo", "chart", "table", "histogram", "gauge", "stat"`,
+				`layout.children[0].component.data.source: unknown data source "ftp", expected one of "script", "api", "stream"`,
+			},
+		},
+		{
+			name: "duplicate ids",
+			doc: `{"layout": {"type": "container", "children": [
+				{"type": "component", "component": {"id": "a", "type": "text", "title": "A", "data": {"source": "script", "command": "echo"}}},
+				{"type": "component", "component": {"id": "a", "type": "text", "title": "B", "data": {"source": "script", "command": "echo"}}}]}}`,
+			want: []string{`layout.children[1].component.id: duplicate id "a", already used at layout.children[0].component.id`},
+		},
+		{
+			name: "source specific keys",
+			doc:  component(`{"type": "text", "title": "T", "data": {"source": "script", "command": "echo", "method": "POST", "url": "http://x", "restart_delay": 2}}`),
+			want: []string{
+				"layout.component.data.method: only used by api sources",
+				"layout.component.data.url: only used by api sources",
+				"layout.component.data.restart_delay: only used by stream sources",
+			},
+		},
+		{
+			name: "component specific keys",
+			doc:  component(`{"type": "text", "title": "T", "data": {"source": "script", "command": "echo", "min": 1, "unit": "%", "header": true}}`),
+			want: []string{
+				"layout.component.data.min: only used by gauge components",
+				"layout.component.data.unit: only used by gauge and stat components",
+				"layout.component.data.header: only used by table components",
+			},
+		},
+		{
+			name: "ranges and colors",
+			doc: `{"style": {"border": {"color": "blue"}},
+				"layout": {"type": "component", "component": {"type": "gauge", "title": "G", "data": {"source": "script", "command": "echo", "min": 10, "max": 5, "timeout": -1}}}}`,
+			want: []string{
+				`style.border.color: invalid color "blue", expected #rrggbb`,
+				"layout.component.data.max: must be greater than min",
+				"layout.component.data.timeout: must not be negative",
+			},
+		},
+		{
+			name: "todo source",
+			doc:  component(`{"type": "todo", "title": "T", "data": {"source": "script"}}`),
+			want: []string{"layout.component.data.source: todo components read a file, set source to the path of a todo file"},
+		},
+		{
+			name: "table sort",
+			doc:  component(`{"type": "table", "title": "T", "data": {"source": "script", "command": "ps", "sort": {"column": "mem"}, "columns": [{"label": "CPU"}]}}`),
+			want: []string{`layout.component.data.sort.column: no column with label or field "mem"`},
+		},
+		{
+			name: "linked components",
+			doc: `{"layout": {"type": "container", "children": [
+				{"type": "component", "component": {"id": "t", "type": "text", "title": "T", "data": {"source": "script", "command": "echo", "selection_from": "t"}}},
+				{"type": "component", "component": {"type": "text", "title": "U", "data": {"source": "script", "command": "echo", "selection_from": "nope"}}}]}}`,
+			want: []string{
+				"layout.children[0].component.data.selection_from: a component cannot follow its own selection",
+				`layout.children[1].component.data.selection_from: no component with id "nope"`,
+			},
+		},
+		{
+			name: "alerts",
+			doc: component(`{"type": "text", "title": "T", "data": {"source": "script", "command": "echo"},
+				"alerts": [{"condition": "value"}, {"condition": "error", "above": 1}, {"condition": "lines", "above": 1, "webhook": "ftp://x"}]}`),
+			want: []string{
+				"layout.component.alerts[0]: set at least one of above, below or equals",
+				"layout.component.alerts[1].above: not used by error alerts",
+				"layout.component.alerts[2].webhook: must be an http or https url",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := validateDoc(t, tt.doc)
+			for _, want := range tt.want {
+				if !slices.Contains(problems, want) {
+					t.Errorf("missing problem %q in %q", want, problems)
+				}
+			}
+			if len(problems) != len(tt.want) {
+				t.Errorf("got %d problems, want %d: %q", len(problems), len(tt.want), problems)
+			}
+		})
+	}
+}
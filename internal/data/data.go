@@ -1,7 +1,9 @@
 package data
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,16 +13,19 @@ import (
 	"time"
 
 	"github.com/oliveagle/jsonpath"
+	"github.com/rasjonell/dashbrew/internal/config"
 )
 
 type FetchOutput interface {
 	Error() error
 	Output() string
+	FetchedAt() time.Time
 }
 
 type fetchOutput struct {
-	err    error
-	output string
+	err       error
+	output    string
+	fetchedAt time.Time
 }
 
 type TodoOutput struct {
@@ -28,34 +33,62 @@ type TodoOutput struct {
 	Title string
 }
 
-func (f *fetchOutput) Error() error   { return f.err }
-func (f *fetchOutput) Output() string { return f.output }
+func (f *fetchOutput) Error() error         { return f.err }
+func (f *fetchOutput) Output() string       { return f.output }
+func (f *fetchOutput) FetchedAt() time.Time { return f.fetchedAt }
 
 func NewFetchOutput(output string, err error) *fetchOutput {
+	return NewFetchOutputAt(output, err, time.Now())
+}
+
+// NewFetchOutputAt creates an output that was fetched at t, e.g. one that
+// is restored from the history store.
+func NewFetchOutputAt(output string, err error, t time.Time) *fetchOutput {
 	return &fetchOutput{
-		err:    err,
-		output: output,
+		err:       err,
+		output:    output,
+		fetchedAt: t,
 	}
 }
 
-func RunScript(command string) FetchOutput {
-	cmd := exec.Command("sh", "-c", command)
-	out, err := cmd.CombinedOutput()
+// shellCommand builds a `sh -c` command that is killed together with its
+// whole process group once ctx is done.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	setProcessGroup(cmd)
+	cmd.WaitDelay = time.Second
+	return cmd
+}
+
+func RunScript(ctx context.Context, command string) FetchOutput {
+	out, err := shellCommand(ctx, command).CombinedOutput()
+	if ctxErr := contextError(ctx); ctxErr != nil {
+		err = ctxErr
+	}
 	return NewFetchOutput(string(out), err)
 }
 
-func RunAPI(url, jsonPath string) FetchOutput {
-	if url == "" {
+func RunAPI(ctx context.Context, cfg *config.DataConfig) FetchOutput {
+	if cfg.URL == "" {
 		return NewFetchOutput("", fmt.Errorf("Empty URL"))
 	}
 
-	client := &http.Client{
-		Timeout: 5 * time.Second,
+	req, err := buildRequest(ctx, cfg)
+	if err != nil {
+		return NewFetchOutput("", fmt.Errorf("Invalid HTTP request: %w", err))
 	}
 
-	resp, err := client.Get(url)
+	client, err := httpClient(cfg.TLS)
 	if err != nil {
-		return NewFetchOutput("", fmt.Errorf("HTTP GET Error: %w", err))
+		return NewFetchOutput("", fmt.Errorf("Invalid TLS config: %w", err))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		if ctxErr := contextError(ctx); ctxErr != nil {
+			return NewFetchOutput("", ctxErr)
+		}
+		return NewFetchOutput("", fmt.Errorf("HTTP %s Error: %w", req.Method, err))
 	}
 	defer resp.Body.Close()
 
@@ -73,14 +106,18 @@ func RunAPI(url, jsonPath string) FetchOutput {
 		return NewFetchOutput("", fmt.Errorf("Failed to read response body: %w", err))
 	}
 
-	if jsonPath == "" {
+	if cfg.JSONPath == "" {
 		return NewFetchOutput(string(bodyBytes), nil)
 	}
 
+	return lookupJSONPath(bodyBytes, cfg.JSONPath)
+}
+
+func lookupJSONPath(raw []byte, jsonPath string) FetchOutput {
 	var jsonData any
-	err = json.Unmarshal(bodyBytes, &jsonData)
+	err := json.Unmarshal(raw, &jsonData)
 	if err != nil {
-		return NewFetchOutput("", fmt.Errorf("Failed to parse API response: %w", err))
+		return NewFetchOutput("", fmt.Errorf("Failed to parse JSON data: %w", err))
 	}
 
 	res, err := jsonpath.JsonPathLookup(jsonData, jsonPath)
@@ -104,6 +141,16 @@ func RunAPI(url, jsonPath string) FetchOutput {
 	return NewFetchOutput(string(resultBytes), nil)
 }
 
+func contextError(ctx context.Context) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("Fetch timed out")
+	case errors.Is(ctx.Err(), context.Canceled):
+		return fmt.Errorf("Fetch cancelled")
+	}
+	return nil
+}
+
 func ReadTodoFile(path string) ([]*TodoOutput, error) {
 	bytes, err := os.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,226 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+const (
+	defaultHistoryRetention  = 7 * 24 * time.Hour
+	defaultHistoryMaxRecords = 10000
+)
+
+// HistoryStore keeps fetch results in one append-only JSON lines file per
+// component. Files are compacted to the retention limits when they are
+// loaded or first recorded to in a run, and whenever they outgrow them by a
+// quarter.
+type HistoryStore struct {
+	dir        string
+	retention  time.Duration
+	maxRecords int
+
+	mu    sync.Mutex
+	lines map[string]int
+}
+
+type historyRecord struct {
+	Time   time.Time `json:"t"`
+	Output string    `json:"output,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// OpenHistory creates the history directory, a nil cfg disables the store.
+func OpenHistory(cfg *config.HistoryConfig) (*HistoryStore, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	dir := cfg.Dir
+	if dir == "" {
+		stateDir, err := stateHome()
+		if err != nil {
+			return nil, err
+		}
+		dir = filepath.Join(stateDir, "dashbrew", "history")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	s := &HistoryStore{
+		dir:        dir,
+		retention:  defaultHistoryRetention,
+		maxRecords: defaultHistoryMaxRecords,
+		lines:      make(map[string]int),
+	}
+	if cfg.RetentionHours > 0 {
+		s.retention = time.Duration(cfg.RetentionHours) * time.Hour
+	}
+	if cfg.MaxRecords > 0 {
+		s.maxRecords = cfg.MaxRecords
+	}
+
+	return s, nil
+}
+
+func stateHome() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return dir, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("Cannot locate history directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state"), nil
+}
+
+func (s *HistoryStore) path(id string) string {
+	return filepath.Join(s.dir, url.PathEscape(id)+".jsonl")
+}
+
+// Record appends result to the history of the component id.
+func (s *HistoryStore) Record(id string, result FetchOutput) error {
+	record := historyRecord{Time: result.FetchedAt(), Output: result.Output()}
+	if result.Error() != nil {
+		record.Error = result.Error().Error()
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The first record of a run applies the limits to what earlier runs
+	// left behind, components that are never loaded are only compacted here.
+	if _, counted := s.lines[id]; !counted {
+		if _, err := s.compact(id); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(s.path(id), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	s.lines[id]++
+	if s.lines[id] > s.maxRecords+s.maxRecords/4 {
+		_, err := s.compact(id)
+		return err
+	}
+	return nil
+}
+
+// Load returns the recorded results of the component id that are within the
+// retention limits, oldest first.
+func (s *HistoryStore) Load(id string) ([]FetchOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.compact(id)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]FetchOutput, len(records))
+	for i, record := range records {
+		var err error
+		if record.Error != "" {
+			err = errors.New(record.Error)
+		}
+		results[i] = NewFetchOutputAt(record.Output, err, record.Time)
+	}
+	return results, nil
+}
+
+// compact drops expired and surplus records from the file of id and returns
+// the records that are left. Lines that fail to decode are skipped.
+func (s *HistoryStore) compact(id string) ([]historyRecord, error) {
+	file, err := os.Open(s.path(id))
+	if errors.Is(err, os.ErrNotExist) {
+		s.lines[id] = 0
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-s.retention)
+	var records []historyRecord
+	total := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		total++
+		var record historyRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Time.Before(cutoff) {
+			continue
+		}
+		records = append(records, record)
+	}
+	file.Close()
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(records) > s.maxRecords {
+		records = records[len(records)-s.maxRecords:]
+	}
+	s.lines[id] = len(records)
+
+	if total == len(records) {
+		return records, nil
+	}
+
+	return records, s.rewrite(id, records)
+}
+
+// rewrite replaces the file of id through a rename so a crash never leaves
+// a truncated history behind.
+func (s *HistoryStore) rewrite(id string, records []historyRecord) error {
+	tmp, err := os.CreateTemp(s.dir, ".history-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path(id))
+}
@@ -0,0 +1,79 @@
+package data
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	n := 0
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		n++
+	}
+	return n
+}
+
+func TestHistoryCompactsAcrossRuns(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.HistoryConfig{Dir: dir, MaxRecords: 4, RetentionHours: 1}
+
+	// Every run records a few results without ever loading them, like a
+	// table whose history is kept but not replayed.
+	for run := range 5 {
+		store, err := OpenHistory(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range 3 {
+			if err := store.Record("tbl", NewFetchOutput(strconv.Itoa(run*3+i), nil)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	store, _ := OpenHistory(cfg)
+	if n := countLines(t, store.path("tbl")); n > 4+3 {
+		t.Errorf("history has %d lines after 5 runs, want at most %d", n, 4+3)
+	}
+}
+
+func TestHistoryDropsExpiredOnFirstRecord(t *testing.T) {
+	cfg := &config.HistoryConfig{Dir: t.TempDir(), RetentionHours: 1}
+
+	store, _ := OpenHistory(cfg)
+	for i := range 3 {
+		if err := store.Record("txt", NewFetchOutputAt("old", nil, time.Now().Add(-2*time.Hour+time.Duration(i)))); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store, _ = OpenHistory(cfg)
+	if err := store.Record("txt", NewFetchOutput("new", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countLines(t, store.path("txt")); n != 1 {
+		t.Errorf("history file has %d lines, want 1", n)
+	}
+
+	results, err := store.Load("txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Output() != "new" {
+		t.Errorf("loaded %d results, want only the new one", len(results))
+	}
+}
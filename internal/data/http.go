@@ -0,0 +1,143 @@
+package data
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+// clients caches one http.Client per set of TLS settings so connections are
+// reused across refreshes. It is keyed by value because every config reload
+// allocates new TLSConfig structs.
+var clients sync.Map
+
+func buildRequest(ctx context.Context, cfg *config.DataConfig) (*http.Request, error) {
+	reqURL, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Query) > 0 {
+		query := reqURL.Query()
+		for k, v := range cfg.Query {
+			query.Set(k, v)
+		}
+		reqURL.RawQuery = query.Encode()
+	}
+
+	method := http.MethodGet
+	if cfg.Method != "" {
+		method = strings.ToUpper(cfg.Method)
+	}
+
+	body, contentType, err := requestBody(cfg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for k, v := range cfg.Headers {
+		req.Header.Set(k, v)
+	}
+
+	if cfg.Auth != nil {
+		switch cfg.Auth.Type {
+		case "basic":
+			req.SetBasicAuth(cfg.Auth.Username, cfg.Auth.Password)
+		case "bearer":
+			req.Header.Set("Authorization", "Bearer "+cfg.Auth.Token)
+		default:
+			return nil, fmt.Errorf("unknown auth type %q", cfg.Auth.Type)
+		}
+	}
+
+	return req, nil
+}
+
+// requestBody sends string bodies verbatim and encodes anything else as JSON.
+func requestBody(body any) (io.Reader, string, error) {
+	switch b := body.(type) {
+	case nil:
+		return nil, "", nil
+	case string:
+		return strings.NewReader(b), "", nil
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode request body: %w", err)
+		}
+		return bytes.NewReader(encoded), "application/json", nil
+	}
+}
+
+func httpClient(tlsCfg *config.TLSConfig) (*http.Client, error) {
+	if tlsCfg == nil {
+		return http.DefaultClient, nil
+	}
+
+	if client, ok := clients.Load(*tlsCfg); ok {
+		return client.(*http.Client), nil
+	}
+
+	clientTLS, err := buildTLSConfig(tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = clientTLS
+
+	client, _ := clients.LoadOrStore(*tlsCfg, &http.Client{Transport: transport})
+	return client.(*http.Client), nil
+}
+
+func buildTLSConfig(cfg *config.TLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+		tlsCfg.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsCfg, nil
+}
@@ -0,0 +1,57 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// OpenURL hands url to the default handler of the platform, e.g. the
+// browser for web pages.
+func OpenURL(ctx context.Context, url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "open", url)
+	case "windows":
+		cmd = exec.CommandContext(ctx, "rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.CommandContext(ctx, "xdg-open", url)
+	}
+
+	return runHelper(cmd)
+}
+
+// Notify shows a desktop notification with notify-send on Linux and
+// osascript on macOS.
+func Notify(ctx context.Context, title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "windows":
+		return fmt.Errorf("Notifications are not supported on %s", runtime.GOOS)
+	default:
+		cmd = exec.CommandContext(ctx, "notify-send", title, message)
+	}
+
+	return runHelper(cmd)
+}
+
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// runHelper runs cmd and adds its output to the error when it fails.
+func runHelper(cmd *exec.Cmd) error {
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package data
+
+import "os/exec"
+
+// setProcessGroup is a no-op on windows, cancelling kills the shell only.
+func setProcessGroup(cmd *exec.Cmd) {}
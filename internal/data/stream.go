@@ -0,0 +1,135 @@
+package data
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// maxStreamLine is the longest line a stream command may print.
+const maxStreamLine = 1024 * 1024
+
+// Stream keeps a long-running command alive and emits every line it prints.
+// When the command exits it is restarted after the configured delay until
+// Stop is called.
+type Stream struct {
+	command      string
+	jsonPath     string
+	restartDelay time.Duration
+
+	out    chan FetchOutput
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func StartStream(command, jsonPath string, restartDelay time.Duration) *Stream {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Stream{
+		command:      command,
+		jsonPath:     jsonPath,
+		restartDelay: restartDelay,
+
+		out:    make(chan FetchOutput),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	go s.run()
+	return s
+}
+
+// Output is closed once the stream is stopped.
+func (s *Stream) Output() <-chan FetchOutput { return s.out }
+
+func (s *Stream) Stop() { s.cancel() }
+
+func (s *Stream) run() {
+	defer close(s.out)
+
+	for {
+		err := s.runOnce()
+		if s.ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			s.send(NewFetchOutput("", fmt.Errorf("Stream exited: %w (restarting in %s)", err, s.restartDelay)))
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-time.After(s.restartDelay):
+		}
+	}
+}
+
+func (s *Stream) runOnce() error {
+	ctx, cancel := context.WithCancel(s.ctx)
+	defer cancel()
+
+	cmd := shellCommand(ctx, s.command)
+
+	pr, pw := io.Pipe()
+	cmd.Stdout = pw
+	cmd.Stderr = pw
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	waitErr := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		pw.Close()
+		waitErr <- err
+	}()
+
+	scanner := bufio.NewScanner(pr)
+	scanner.Buffer(make([]byte, 64*1024), maxStreamLine)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if !s.send(s.parseLine(line)) {
+			break
+		}
+	}
+	// Drain whatever is left so the writer never blocks on an abandoned pipe.
+	go io.Copy(io.Discard, pr)
+
+	// A line that doesn't fit the buffer ends scanning while the command
+	// keeps running, it is killed so that the stream restarts.
+	if err := scanner.Err(); err != nil {
+		cancel()
+		<-waitErr
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("line longer than %d bytes", maxStreamLine)
+		}
+		return err
+	}
+
+	return <-waitErr
+}
+
+func (s *Stream) parseLine(line string) FetchOutput {
+	if s.jsonPath == "" {
+		return NewFetchOutput(line, nil)
+	}
+	return lookupJSONPath([]byte(line), s.jsonPath)
+}
+
+func (s *Stream) send(out FetchOutput) bool {
+	select {
+	case s.out <- out:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
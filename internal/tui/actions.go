@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/rasjonell/dashbrew/internal/components"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const (
+	actionTimeout = 30 * time.Second
+	toastDuration = 4 * time.Second
+)
+
+type actionResultMsg struct {
+	title  string
+	output string
+	err    error
+}
+
+type clearToastMsg struct {
+	seq int
+}
+
+// toast is a message shown over the bottom line until it expires.
+type toast struct {
+	text  string
+	isErr bool
+	seq   int
+}
+
+// pendingAction waits for the user to confirm it.
+type pendingAction struct {
+	prompt string
+	run    tea.Cmd
+}
+
+func actionBindings(comp components.Component) []key.Binding {
+	var bindings []key.Binding
+	for _, action := range comp.Config().Actions {
+		if action != nil {
+			bindings = append(bindings, components.NewBinding(action.Keys(), action.Title()))
+		}
+	}
+	return bindings
+}
+
+// startAction runs the action of comp bound to msg for the selected row.
+// It reports false when no action is bound to the key.
+func (m *model) startAction(comp components.Component, msg tea.KeyMsg) (tea.Cmd, bool) {
+	for _, action := range comp.Config().Actions {
+		if action == nil || !key.Matches(msg, components.NewBinding(action.Keys(), "")) {
+			continue
+		}
+
+		row := comp.SelectedRow()
+		if row == nil {
+			return m.showToast("Nothing selected", true), true
+		}
+
+		target, err := action.Expand(row)
+		if err != nil {
+			return m.showToast(action.Title()+": "+err.Error(), true), true
+		}
+
+		run := runAction(action, target)
+		if action.Confirm {
+			prompt := target
+			if action.Label != "" {
+				prompt = action.Label + ": " + target
+			}
+			m.confirm = &pendingAction{prompt: prompt, run: run}
+			return nil, true
+		}
+		return run, true
+	}
+	return nil, false
+}
+
+func runAction(action *config.ActionConfig, target string) tea.Cmd {
+	title := action.Title()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+		defer cancel()
+
+		if action.URL != "" {
+			return actionResultMsg{title: title, output: "Opened " + target, err: data.OpenURL(ctx, target)}
+		}
+
+		result := data.RunScript(ctx, target)
+		return actionResultMsg{title: title, output: result.Output(), err: result.Error()}
+	}
+}
+
+// handleConfirmKey runs the pending action on "y" and drops it on any
+// other key.
+func (m *model) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	pending := m.confirm
+	m.confirm = nil
+
+	if strings.EqualFold(msg.String(), "y") {
+		return pending.run
+	}
+	return m.showToast("Cancelled", false)
+}
+
+func (m *model) handleActionResult(msg actionResultMsg) tea.Cmd {
+	output := strings.TrimSpace(msg.output)
+	if msg.err != nil {
+		text := msg.title + " failed: " + msg.err.Error()
+		if output != "" {
+			text += ": " + firstLine(output)
+		}
+		return m.showToast(text, true)
+	}
+
+	if output == "" {
+		return m.showToast(msg.title+" done", false)
+	}
+	return m.showToast(firstLine(output), false)
+}
+
+func (m *model) showToast(text string, isErr bool) tea.Cmd {
+	seq := 1
+	if m.toast != nil {
+		seq = m.toast.seq + 1
+	}
+	m.toast = &toast{text: text, isErr: isErr, seq: seq}
+
+	return tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return clearToastMsg{seq: seq}
+	})
+}
+
+// renderStatus returns the confirm prompt or the current toast, drawn over
+// the last line of the screen.
+func (m *model) renderStatus() string {
+	style := lipgloss.NewStyle().Width(m.width).MaxWidth(m.width).Bold(true).Padding(0, 1)
+	line := func(text string) string {
+		text = strings.ReplaceAll(text, "\n", " ")
+		return runewidth.Truncate(text, max(0, m.width-2), "…")
+	}
+
+	switch {
+	case m.confirm != nil:
+		return style.
+			Foreground(lipgloss.Color("#000000")).
+			Background(lipgloss.Color("#ffaa00")).
+			Render(line(m.confirm.prompt + "? [y/N]"))
+	case m.toast != nil && m.toast.isErr:
+		return style.
+			Foreground(lipgloss.Color("#ffffff")).
+			Background(lipgloss.Color("#aa0000")).
+			Render(line(m.toast.text))
+	case m.toast != nil:
+		return style.
+			Foreground(lipgloss.Color("#ffffff")).
+			Background(lipgloss.Color("#005f00")).
+			Render(line(m.toast.text))
+	default:
+		return ""
+	}
+}
+
+func firstLine(s string) string {
+	line, rest, _ := strings.Cut(s, "\n")
+	if rest != "" {
+		line += " …"
+	}
+	return line
+}
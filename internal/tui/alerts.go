@@ -0,0 +1,384 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/rasjonell/dashbrew/internal/components"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const (
+	alertHookTimeout   = 10 * time.Second
+	alertFlashInterval = 500 * time.Millisecond
+	defaultAlertColor  = "#d75f5f"
+)
+
+// componentAlerts holds the alert states of one component config, they
+// start over when the config is replaced by a reload.
+type componentAlerts struct {
+	cfg    *config.Component
+	states []*alertState
+}
+
+type alertState struct {
+	cfg     *config.AlertConfig
+	since   time.Time
+	firedAt time.Time
+	firing  bool
+	value   string
+}
+
+type alertFlashMsg struct{}
+
+type alertHookMsg struct {
+	title string
+	err   error
+}
+
+func (m *model) componentAlerts(comp components.Component) *componentAlerts {
+	cfg := comp.Config()
+	if alerts, ok := m.alerts[comp.ID()]; ok && alerts.cfg == cfg {
+		return alerts
+	}
+
+	alerts := &componentAlerts{cfg: cfg}
+	for _, alert := range cfg.Alerts {
+		if alert != nil {
+			alerts.states = append(alerts.states, &alertState{cfg: alert})
+		}
+	}
+	m.alerts[comp.ID()] = alerts
+	return alerts
+}
+
+// evaluateAlerts checks the alerts of id against a fetch result. An alert
+// fires once its condition held for its "for" duration and resolves with
+// the first result that doesn't match.
+func (m *model) evaluateAlerts(id string, result data.FetchOutput) tea.Cmd {
+	comp, ok := m.components[id]
+	if !ok || len(comp.Config().Alerts) == 0 {
+		return nil
+	}
+
+	firingBefore := m.firingCount()
+	now := time.Now()
+
+	var cmds []tea.Cmd
+	for _, state := range m.componentAlerts(comp).states {
+		holds, value, ok := checkAlert(state.cfg, result)
+		if !ok {
+			continue
+		}
+		state.value = value
+
+		if !holds {
+			state.since = time.Time{}
+			if state.firing {
+				state.firing = false
+				cmds = append(cmds, alertHooks(comp, state, "resolved"))
+			}
+			continue
+		}
+
+		if state.since.IsZero() {
+			state.since = now
+		}
+		if !state.firing && now.Sub(state.since) >= time.Duration(state.cfg.For)*time.Second {
+			state.firing = true
+			state.firedAt = now
+			cmds = append(cmds, alertHooks(comp, state, "firing"))
+		}
+	}
+
+	cmds = append(cmds, m.startFlashing())
+	m.applyAlertBorder(id)
+
+	// The alert bar takes a line from the layout while anything fires.
+	if (firingBefore == 0) != (m.firingCount() == 0) {
+		m.relayout()
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// checkAlert reports whether the condition of alert holds for result and
+// the value it looked at. ok is false when result says nothing about the
+// condition, e.g. a failed fetch for a value alert.
+func checkAlert(alert *config.AlertConfig, result data.FetchOutput) (holds bool, value string, ok bool) {
+	if alert.Condition == "error" {
+		if err := result.Error(); err != nil {
+			return true, firstLine(err.Error()), true
+		}
+		return false, "", true
+	}
+
+	if result.Error() != nil {
+		return false, "", false
+	}
+
+	switch alert.Condition {
+	case "lines":
+		count := 0
+		for _, line := range strings.Split(result.Output(), "\n") {
+			if strings.TrimSpace(line) != "" {
+				count++
+			}
+		}
+		return compareAlert(alert, float64(count), true), strconv.Itoa(count), true
+
+	case "value":
+		text, ok := alertValue(result.Output())
+		if !ok {
+			return false, "", false
+		}
+		number, isNumber := alertNumber(text)
+		if alert.Equals != nil && !alertEquals(alert.Equals, text, number, isNumber) {
+			return false, text, true
+		}
+		return compareAlert(alert, number, isNumber), text, true
+	}
+
+	return false, "", false
+}
+
+// alertNumber parses a value like "42", "1e3" or "97.5%".
+func alertNumber(text string) (float64, bool) {
+	number, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(text), "%"), 64)
+	return number, err == nil
+}
+
+// alertEquals compares the value with the equals of an alert, numerically
+// when both are numbers so that 1 matches "1.0", otherwise as text.
+func alertEquals(expected any, text string, number float64, isNumber bool) bool {
+	want := strings.TrimSpace(fmt.Sprint(expected))
+	if wantNumber, ok := alertNumber(want); ok && isNumber {
+		return wantNumber == number
+	}
+	return want == strings.TrimSpace(text)
+}
+
+// compareAlert checks the above and below limits of alert, a value that
+// isn't a number only matches alerts without limits.
+func compareAlert(alert *config.AlertConfig, value float64, isNumber bool) bool {
+	if alert.Above == nil && alert.Below == nil {
+		return true
+	}
+	if !isNumber {
+		return false
+	}
+	if alert.Above != nil && value <= *alert.Above {
+		return false
+	}
+	if alert.Below != nil && value >= *alert.Below {
+		return false
+	}
+	return true
+}
+
+// alertValue is the last non-empty line of output, or its "value" field
+// when the line is a JSON object.
+func alertValue(output string) (string, bool) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	last := strings.TrimSpace(lines[len(lines)-1])
+	if last == "" {
+		return "", false
+	}
+
+	if strings.HasPrefix(last, "{") {
+		var reading struct {
+			Value any `json:"value"`
+		}
+		if err := json.Unmarshal([]byte(last), &reading); err != nil || reading.Value == nil {
+			return "", false
+		}
+		return fmt.Sprint(reading.Value), true
+	}
+	return last, true
+}
+
+// alertHooks rings the bell and sends the notification of a firing alert
+// and runs its command and webhook whenever its state changes.
+func alertHooks(comp components.Component, state *alertState, status string) tea.Cmd {
+	alert := state.cfg
+	title := comp.Config().Title
+	event := map[string]any{
+		"name":      alert.Title(),
+		"component": comp.ID(),
+		"title":     title,
+		"state":     status,
+		"value":     state.value,
+	}
+
+	var cmds []tea.Cmd
+	if status == "firing" && alert.Bell {
+		cmds = append(cmds, func() tea.Msg {
+			os.Stdout.WriteString("\a")
+			return nil
+		})
+	}
+
+	if status == "firing" && alert.Notify {
+		message := state.value
+		if title != "" {
+			message = title + ": " + message
+		}
+		cmds = append(cmds, runAlertHook(alert.Title(), func(ctx context.Context) error {
+			return data.Notify(ctx, "⚠ "+alert.Title(), message)
+		}))
+	}
+
+	if alert.Command != "" {
+		command, err := alert.ExpandCommand(event)
+		cmds = append(cmds, runAlertHook(alert.Title(), func(ctx context.Context) error {
+			if err != nil {
+				return err
+			}
+			return data.RunScript(ctx, command).Error()
+		}))
+	}
+
+	if alert.Webhook != "" {
+		webhook := &config.DataConfig{Source: "api", URL: alert.Webhook, Method: "POST", Body: event}
+		cmds = append(cmds, runAlertHook(alert.Title(), func(ctx context.Context) error {
+			return data.RunAPI(ctx, webhook).Error()
+		}))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+func runAlertHook(title string, hook func(ctx context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), alertHookTimeout)
+		defer cancel()
+
+		if err := hook(ctx); err != nil {
+			return alertHookMsg{title: title, err: err}
+		}
+		return nil
+	}
+}
+
+// applyAlertBorder colors the border of id with its first firing alert,
+// flashing alerts leave it out every other tick.
+func (m *model) applyAlertBorder(id string) {
+	comp, ok := m.components[id]
+	if !ok {
+		return
+	}
+
+	color := ""
+	for _, state := range m.componentAlerts(comp).states {
+		if !state.firing || (state.cfg.Flash && !m.flashOn) {
+			continue
+		}
+		color = state.cfg.Color
+		if color == "" {
+			color = defaultAlertColor
+		}
+		break
+	}
+	comp.SetAlert(color)
+}
+
+// startFlashing starts the flash ticks unless they are already running or
+// no flashing alert fires.
+func (m *model) startFlashing() tea.Cmd {
+	if m.flashing || !m.anyFlashing() {
+		return nil
+	}
+	m.flashing = true
+	m.flashOn = true
+	return flashTick()
+}
+
+func (m *model) handleAlertFlash() tea.Cmd {
+	m.flashOn = !m.flashOn
+	for id := range m.alerts {
+		m.applyAlertBorder(id)
+	}
+
+	if !m.anyFlashing() {
+		m.flashing = false
+		return nil
+	}
+	return flashTick()
+}
+
+func flashTick() tea.Cmd {
+	return tea.Tick(alertFlashInterval, func(time.Time) tea.Msg {
+		return alertFlashMsg{}
+	})
+}
+
+func (m *model) anyFlashing() bool {
+	for _, state := range m.firingAlerts() {
+		if state.cfg.Flash {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *model) firingCount() int {
+	return len(m.firingAlerts())
+}
+
+// firingAlerts returns every firing alert of the current components in the
+// order they fired.
+func (m *model) firingAlerts() []*alertState {
+	var firing []*alertState
+	for id, alerts := range m.alerts {
+		if comp, ok := m.components[id]; !ok || comp.Config() != alerts.cfg {
+			continue
+		}
+		for _, state := range alerts.states {
+			if state.firing {
+				firing = append(firing, state)
+			}
+		}
+	}
+
+	sort.SliceStable(firing, func(i, j int) bool {
+		return firing[i].firedAt.Before(firing[j].firedAt)
+	})
+	return firing
+}
+
+// renderAlerts is the global alert status line, shown while any alert
+// fires.
+func (m *model) renderAlerts() string {
+	firing := m.firingAlerts()
+	if len(firing) == 0 || m.width == 0 {
+		return ""
+	}
+
+	names := make([]string, len(firing))
+	for i, state := range firing {
+		names[i] = state.cfg.Title()
+		if state.value != "" {
+			names[i] += " (" + state.value + ")"
+		}
+	}
+
+	text := fmt.Sprintf("⚠ %d alert(s) firing: %s", len(firing), strings.Join(names, ", "))
+	return lipgloss.NewStyle().
+		Width(m.width).
+		MaxWidth(m.width).
+		Bold(true).
+		Padding(0, 1).
+		Foreground(lipgloss.Color("#ffffff")).
+		Background(lipgloss.Color("#870000")).
+		Render(runewidth.Truncate(text, max(0, m.width-2), "…"))
+}
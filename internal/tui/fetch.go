@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/rasjonell/dashbrew/internal/components"
@@ -9,38 +11,138 @@ import (
 	"github.com/rasjonell/dashbrew/internal/data"
 )
 
+const defaultAPITimeout = 5
+
 type fetchResultMsg struct {
 	ID     string
 	Result data.FetchOutput
+	seq    uint64
+}
+
+type inFlightFetch struct {
+	seq    uint64
+	cancel context.CancelFunc
 }
 
+// fetchAllData fetches every component once, streams on hidden pages are
+// only started when their page is shown.
 func (m *model) fetchAllData() []tea.Cmd {
 	var cmds []tea.Cmd
 	for id, comp := range m.components {
-		cmds = append(cmds, fetchComponentAsyncCmd(id, comp.Config()))
+		if isStream(comp.Config()) && !m.isVisible(id) {
+			m.paused[id] = false
+			continue
+		}
+		cmds = append(cmds, m.fetchComponent(id, comp.Config()))
 	}
 	return cmds
 }
 
-func fetchComponentAsyncCmd(id string, comp *config.Component) tea.Cmd {
+// fetchComponent (re)starts streaming components and runs a one-off fetch
+// for everything else. A fetch is skipped while a previous one for the same
+// component is still running, or while the component it follows has nothing
+// selected.
+func (m *model) fetchComponent(id string, comp *config.Component) tea.Cmd {
+	comp, ok, err := m.linkedConfig(comp)
+	switch {
+	case !ok:
+		return nil
+	case err != nil:
+		return m.failFetch(id, err)
+	}
+
+	if isStream(comp) {
+		return m.startStream(id, comp)
+	}
+
+	if _, running := m.inFlight[id]; running {
+		return nil
+	}
+
+	ctx, cancel := fetchContext(comp)
+	m.fetchSeq++
+	m.inFlight[id] = &inFlightFetch{seq: m.fetchSeq, cancel: cancel}
+
+	return fetchComponentAsyncCmd(ctx, cancel, id, m.fetchSeq, comp)
+}
+
+// refetchComponent cancels a running fetch before starting a fresh one.
+func (m *model) refetchComponent(id string, comp *config.Component) tea.Cmd {
+	m.cancelFetch(id)
+	return m.fetchComponent(id, comp)
+}
+
+func (m *model) cancelFetch(id string) {
+	if fetch, ok := m.inFlight[id]; ok {
+		fetch.cancel()
+		delete(m.inFlight, id)
+	}
+}
+
+func (m *model) cancelAllFetches() {
+	for id := range m.inFlight {
+		m.cancelFetch(id)
+	}
+}
+
+// finishFetch reports whether msg belongs to the fetch currently tracked for
+// its component, results of cancelled fetches are dropped.
+func (m *model) finishFetch(msg fetchResultMsg) bool {
+	fetch, ok := m.inFlight[msg.ID]
+	if !ok || fetch.seq != msg.seq {
+		return false
+	}
+
+	delete(m.inFlight, msg.ID)
+	return true
+}
+
+func fetchContext(comp *config.Component) (context.Context, context.CancelFunc) {
+	timeout := 0
+	if comp.Data != nil {
+		timeout = comp.Data.Timeout
+		if timeout <= 0 && comp.Data.Source == "api" {
+			timeout = defaultAPITimeout
+		}
+	}
+
+	if timeout > 0 {
+		return context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	}
+	return context.WithCancel(context.Background())
+}
+
+func fetchComponentAsyncCmd(
+	ctx context.Context,
+	cancel context.CancelFunc,
+	id string,
+	seq uint64,
+	comp *config.Component,
+) tea.Cmd {
 	if comp.Data == nil {
 		return func() tea.Msg {
+			cancel()
 			return fetchResultMsg{
 				ID:     id,
+				seq:    seq,
 				Result: data.NewFetchOutput("", fmt.Errorf("component data source is nil")),
 			}
 		}
 	}
 
 	return func() tea.Msg {
+		defer cancel()
+
 		var result data.FetchOutput
 
 		if comp.Type == "todo" {
 			items, err := data.ReadTodoFile(comp.Data.Source)
 			return fetchResultMsg{
-				ID: id,
+				ID:  id,
+				seq: seq,
 				Result: &components.TodoFetchOutput{
 					Err:       err,
+					At:        time.Now(),
 					TodoItems: items,
 				},
 			}
@@ -48,15 +150,16 @@ func fetchComponentAsyncCmd(id string, comp *config.Component) tea.Cmd {
 
 		switch comp.Data.Source {
 		case "script":
-			result = data.RunScript(comp.Data.Command)
+			result = data.RunScript(ctx, comp.Data.Command)
 		case "api":
-			result = data.RunAPI(comp.Data.URL, comp.Data.JSONPath)
+			result = data.RunAPI(ctx, comp.Data)
 		default:
 			result = data.NewFetchOutput("", fmt.Errorf("unknown data source %s", comp.Data.Source))
 		}
 
 		return fetchResultMsg{
 			ID:     id,
+			seq:    seq,
 			Result: result,
 		}
 	}
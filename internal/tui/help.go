@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rasjonell/dashbrew/internal/components"
+)
+
+// renderHelp draws the global bindings next to the bindings of the focused
+// component, centered in the layout area.
+func (m *model) renderHelp(width, height int) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Underline(true).MarginBottom(1)
+
+	sections := []string{
+		lipgloss.JoinVertical(lipgloss.Left,
+			titleStyle.Render("Dashboard"),
+			m.help.FullHelpView(m.keys.FullHelp()),
+		),
+	}
+
+	if comp, ok := m.components[m.focusedComponentId]; ok {
+		title := comp.Config().Title
+		if title == "" {
+			title = "Untitled"
+		}
+
+		body := "No component specific keys"
+		bindings := append(comp.KeyBindings(), actionBindings(comp)...)
+		if columns := helpColumns(bindings, 4); len(columns) > 0 {
+			body = m.help.FullHelpView(columns)
+		}
+
+		sections = append(sections, lipgloss.JoinVertical(lipgloss.Left,
+			titleStyle.Render(fmt.Sprintf("%s (%s)", title, comp.Type())),
+			body,
+		))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, joinWithGap(sections, 6)...),
+		lipgloss.NewStyle().Faint(true).MarginTop(1).Render(m.keys.Help.Help().Key+" or esc to close"),
+	)
+
+	_, focusedStyle, _ := components.GetBorderStyle(m.cfg.Style.Border)
+	box := focusedStyle.Padding(1, 2).Render(content)
+
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// helpColumns splits bindings into columns of at most n rows, disabled
+// bindings are skipped.
+func helpColumns(bindings []key.Binding, n int) [][]key.Binding {
+	var columns [][]key.Binding
+	var column []key.Binding
+
+	for _, binding := range bindings {
+		if !binding.Enabled() {
+			continue
+		}
+		column = append(column, binding)
+		if len(column) == n {
+			columns = append(columns, column)
+			column = nil
+		}
+	}
+
+	if len(column) > 0 {
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func joinWithGap(blocks []string, gap int) []string {
+	spacer := lipgloss.NewStyle().Width(gap).Render("")
+
+	joined := make([]string, 0, 2*len(blocks))
+	for i, block := range blocks {
+		if i > 0 {
+			joined = append(joined, spacer)
+		}
+		joined = append(joined, block)
+	}
+	return joined
+}
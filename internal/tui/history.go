@@ -0,0 +1,70 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/components"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+// maxHistoryReplay caps how many records are replayed into components that
+// don't set max_points.
+const maxHistoryReplay = 1000
+
+// restoreHistory replays the recorded results of comp so that charts and
+// stat tiles continue where the previous run left off.
+func (m *model) restoreHistory(comp components.Component) components.Component {
+	cfg := comp.Config()
+	if m.history == nil || !cfg.RestoresHistory() {
+		return comp
+	}
+
+	results, err := m.history.Load(comp.ID())
+	if err != nil {
+		return comp
+	}
+
+	limit := maxHistoryReplay
+	if cfg.Data.MaxPoints > 0 {
+		limit = cfg.Data.MaxPoints
+	}
+	if len(results) > limit {
+		results = results[len(results)-limit:]
+	}
+
+	for _, result := range results {
+		if result.Error() != nil {
+			continue
+		}
+		comp, _ = comp.SetContent(result)
+	}
+	return comp
+}
+
+type historyRecordedMsg struct {
+	err error
+}
+
+// recordHistory stores result in the background, a failing write only
+// costs the history and never the dashboard.
+func (m *model) recordHistory(id string, result data.FetchOutput) tea.Cmd {
+	comp, ok := m.components[id]
+	if m.history == nil || !ok || !comp.Config().RecordsHistory() {
+		return nil
+	}
+
+	store := m.history
+	return func() tea.Msg {
+		return historyRecordedMsg{err: store.Record(id, result)}
+	}
+}
+
+// handleHistoryRecorded reports the first failing write, and the next one
+// again only after a write succeeded in between.
+func (m *model) handleHistoryRecorded(msg historyRecordedMsg) tea.Cmd {
+	failing := m.historyErr != nil
+	m.historyErr = msg.err
+	if msg.err == nil || failing {
+		return nil
+	}
+	return m.showToast("Failed to record history: "+msg.err.Error(), true)
+}
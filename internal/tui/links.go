@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+// linkedConfig returns comp with its command and url filled in from the
+// selection of the component it follows. ok is false while that component
+// has nothing selected, the fetch is skipped until it does.
+func (m *model) linkedConfig(comp *config.Component) (linked *config.Component, ok bool, err error) {
+	if !comp.Data.Linked() {
+		return comp, true, nil
+	}
+
+	source, exists := m.components[comp.Data.SelectionFrom]
+	if !exists {
+		return nil, true, fmt.Errorf("No component with id %q to follow", comp.Data.SelectionFrom)
+	}
+
+	row := source.SelectedRow()
+	if row == nil {
+		return nil, false, nil
+	}
+
+	expanded, err := comp.Data.ExpandSelection(row)
+	if err != nil {
+		return nil, true, err
+	}
+
+	copied := *comp
+	copied.Data = expanded
+	return &copied, true, nil
+}
+
+// failFetch reports err as the result of a fetch of id.
+func (m *model) failFetch(id string, err error) tea.Cmd {
+	m.stopStream(id)
+	m.cancelFetch(id)
+
+	m.fetchSeq++
+	seq := m.fetchSeq
+	m.inFlight[id] = &inFlightFetch{seq: seq, cancel: func() {}}
+
+	return func() tea.Msg {
+		return fetchResultMsg{ID: id, seq: seq, Result: data.NewFetchOutput("", err)}
+	}
+}
+
+// followSelections refetches the components that follow a table or list
+// whose selection changed since the last update. Components on hidden
+// pages are fetched once their page is shown.
+func (m *model) followSelections() []tea.Cmd {
+	var cmds []tea.Cmd
+	checked := make(map[string]bool)
+
+	for id, comp := range m.components {
+		cfg := comp.Config()
+		if !cfg.Data.Linked() {
+			continue
+		}
+
+		sourceID := cfg.Data.SelectionFrom
+		if !checked[sourceID] {
+			checked[sourceID] = true
+			m.updateSelection(sourceID)
+		}
+		if !m.selections[sourceID].changed {
+			continue
+		}
+		if !m.isVisible(id) {
+			m.cancelFetch(id)
+			if _, paused := m.paused[id]; !paused {
+				m.paused[id] = false
+			}
+			continue
+		}
+		cmds = append(cmds, m.refetchComponent(id, cfg))
+	}
+
+	for id := range checked {
+		m.selections[id].changed = false
+	}
+	return cmds
+}
+
+type selection struct {
+	key     string
+	changed bool
+}
+
+// updateSelection compares the selected row of id with the one seen last.
+func (m *model) updateSelection(id string) {
+	var key string
+	if source, ok := m.components[id]; ok {
+		if row := source.SelectedRow(); row != nil {
+			key = fmt.Sprint(row)
+		}
+	}
+
+	state, ok := m.selections[id]
+	if !ok {
+		state = &selection{}
+		m.selections[id] = state
+	}
+	state.changed = state.key != key
+	state.key = key
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const linkedPagesDoc = `{"pages": [
+	{"title": "Services", "layout": {"type": "container", "children": [
+		{"type": "component", "component": {"id": "svc", "type": "list", "title": "S", "data": {"source": "script", "command": "ls"}}},
+		{"type": "component", "component": {"id": "near", "type": "text", "title": "N", "data": {"source": "script", "command": "echo {{quote .value}}", "selection_from": "svc"}}}]}},
+	{"title": "Logs", "layout": {"type": "component", "component": {"id": "far", "type": "text", "title": "F",
+		"data": {"source": "script", "command": "echo {{quote .value}}", "selection_from": "svc", "refresh_interval": 60}}}}]}`
+
+func TestFollowSelectionsPausesHiddenComponents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dashboard.json")
+	if err := os.WriteFile(path, []byte(linkedPagesDoc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(cfg, "", nil, nil).(*model)
+	m.Init()
+	defer m.shutdown()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m.cancelAllFetches()
+
+	svc, _ := m.components["svc"].SetContent(data.NewFetchOutput("api\ndb", nil))
+	m.components["svc"] = svc
+
+	cmds := m.followSelections()
+	if len(cmds) != 1 {
+		t.Fatalf("got %d fetches, want one for the visible component", len(cmds))
+	}
+	if _, running := m.inFlight["near"]; !running {
+		t.Error("the visible component was not fetched")
+	}
+	if _, running := m.inFlight["far"]; running {
+		t.Error("the hidden component was fetched")
+	}
+	if stopped, paused := m.paused["far"]; !paused || stopped {
+		t.Errorf("paused[far] = %v, %v; want a pending fetch with a running schedule", stopped, paused)
+	}
+
+	if cmd := m.switchPage(1); cmd == nil {
+		t.Fatal("showing the page did not fetch the hidden component")
+	}
+	if _, running := m.inFlight["far"]; !running {
+		t.Error("the component was not fetched when its page was shown")
+	}
+	if _, paused := m.paused["far"]; paused {
+		t.Error("the component is still paused")
+	}
+}
@@ -3,6 +3,9 @@ package tui
 import (
 	"math"
 
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/rasjonell/dashbrew/internal/components"
 	"github.com/rasjonell/dashbrew/internal/config"
 )
@@ -30,23 +33,70 @@ func (m *model) handleResize(msgWidth, msgHeight int) {
 
 	m.width = w
 	m.height = h
+	m.relayout()
+}
+
+// layoutArea returns the offset and size available to the layout tree below
+// the reload error banner and the tab bar.
+func (m *model) layoutArea() (top, w, h int) {
+	if banner := m.renderBanner(); banner != "" {
+		top = lipgloss.Height(banner)
+	}
+	if tabs := m.renderTabs(); tabs != "" {
+		top += lipgloss.Height(tabs)
+	}
+	if alerts := m.renderAlerts(); alerts != "" {
+		top += lipgloss.Height(alerts)
+	}
+	w, h = evenWidthHeight(m.width, max(0, m.height-top))
+	return top, w, h
+}
+
+// relayout recomputes bounding boxes and the navigation map, it has to run
+// whenever the terminal size or the layout tree changes.
+func (m *model) relayout() {
 	m.ready = false
 
-	if m.cfg == nil || m.cfg.Layout == nil || len(m.components) == 0 {
+	layout := m.currentLayout()
+	if layout == nil || len(m.components) == 0 {
 		m.ready = true
 		return
 	}
 
+	top, w, h := m.layoutArea()
 	newBoxes := make(map[string]*boundingBox)
-	calculateBoundingBoxes(m.cfg.Layout, 0, 0, w, h, newBoxes)
-	m.componentBoxes = newBoxes
+	calculateBoundingBoxes(layout, 0, top, w, h, newBoxes)
+
+	// Navigation always follows the full layout, so moving focus while zoomed
+	// zooms into the neighbouring component.
+	m.navMap = calculateNavigationMap(newBoxes)
+
+	if _, exists := newBoxes[m.focusedComponentId]; !exists {
+		m.focusedComponentId = findFirstComponent(layout)
+	}
 
-	m.navMap = calculateNavigationMap(m.componentBoxes)
+	if m.zoomed {
+		newBoxes = make(map[string]*boundingBox)
+		calculateBoundingBoxes(m.layoutRoot(), 0, top, w, h, newBoxes)
+	}
+
+	m.componentBoxes = newBoxes
 	m.ready = true
+}
+
+// layoutRoot returns the layout tree to render, which is just the focused
+// component while zoomed.
+func (m *model) layoutRoot() *config.LayoutNode {
+	if !m.zoomed {
+		return m.currentLayout()
+	}
 
-	if _, exists := m.componentBoxes[m.focusedComponentId]; !exists {
-		m.focusedComponentId = findFirstComponent(m.cfg.Layout)
+	comp, ok := m.components[m.focusedComponentId]
+	if !ok {
+		return m.currentLayout()
 	}
+
+	return &config.LayoutNode{Type: "component", Component: comp.Config()}
 }
 
 func (m *model) focusClicked(x, y int) {
@@ -58,9 +108,40 @@ func (m *model) focusClicked(x, y int) {
 	}
 }
 
+// localMouse makes the position of msg relative to the top left corner of
+// the focused component.
+func (m *model) localMouse(msg tea.MouseMsg) tea.MouseMsg {
+	if box, ok := m.componentBoxes[m.focusedComponentId]; ok {
+		msg.X -= box.X
+		msg.Y -= box.Y
+	}
+	return msg
+}
+
+func (m *model) moveFocus(msg tea.KeyMsg) {
+	nav, ok := m.navMap[m.focusedComponentId]
+	if !ok {
+		return
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.tryFocus(nav.Up)
+	case key.Matches(msg, m.keys.Down):
+		m.tryFocus(nav.Down)
+	case key.Matches(msg, m.keys.Left):
+		m.tryFocus(nav.Left)
+	case key.Matches(msg, m.keys.Right):
+		m.tryFocus(nav.Right)
+	}
+}
+
 func (m *model) tryFocus(targetID string) {
 	if targetComp, ok := m.components[targetID]; ok && targetComp.IsFocusable() {
 		m.focusedComponentId = targetID
+		if m.zoomed {
+			m.relayout()
+		}
 	}
 }
 
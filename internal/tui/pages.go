@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rasjonell/dashbrew/internal/components"
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+// page keeps the layout of a single tab along with the focus it had when the
+// user last left it.
+type page struct {
+	title              string
+	layout             *config.LayoutNode
+	componentIds       map[string]bool
+	focusedComponentId string
+}
+
+func newPages(cfg *config.DashboardConfig) []*page {
+	if cfg == nil {
+		return nil
+	}
+
+	var pages []*page
+	for i, pageCfg := range cfg.PageList() {
+		if pageCfg == nil || pageCfg.Layout == nil {
+			continue
+		}
+
+		title := pageCfg.Title
+		if title == "" {
+			title = fmt.Sprintf("Page %d", i+1)
+		}
+
+		p := &page{
+			title:              title,
+			layout:             pageCfg.Layout,
+			componentIds:       make(map[string]bool),
+			focusedComponentId: findFirstComponent(pageCfg.Layout),
+		}
+		collectComponentIds(pageCfg.Layout, p.componentIds)
+		pages = append(pages, p)
+	}
+
+	return pages
+}
+
+func collectComponentIds(node *config.LayoutNode, ids map[string]bool) {
+	if node == nil {
+		return
+	}
+	if node.Type == "component" && node.Component != nil {
+		ids[components.ComponentId(node.Component)] = true
+	}
+	for _, child := range node.Children {
+		collectComponentIds(child, ids)
+	}
+}
+
+func (m *model) currentPage() *page {
+	if m.activePage < 0 || m.activePage >= len(m.pages) {
+		return nil
+	}
+	return m.pages[m.activePage]
+}
+
+func (m *model) currentLayout() *config.LayoutNode {
+	if p := m.currentPage(); p != nil {
+		return p.layout
+	}
+	return nil
+}
+
+func (m *model) isVisible(id string) bool {
+	p := m.currentPage()
+	return p != nil && p.componentIds[id]
+}
+
+// switchPage shows the page at idx, restoring its focus and resuming the
+// refresh schedules and streams that were paused while it was hidden.
+func (m *model) switchPage(idx int) tea.Cmd {
+	if len(m.pages) == 0 {
+		return nil
+	}
+
+	idx = (idx + len(m.pages)) % len(m.pages)
+	if idx == m.activePage {
+		return nil
+	}
+
+	m.currentPage().focusedComponentId = m.focusedComponentId
+	m.activePage = idx
+	m.focusedComponentId = m.currentPage().focusedComponentId
+	m.relayout()
+	m.pauseHiddenStreams()
+
+	return m.resumeRefreshes()
+}
+
+func (m *model) resumeRefreshes() tea.Cmd {
+	var cmds []tea.Cmd
+	for id := range m.paused {
+		comp, ok := m.components[id]
+		if !ok {
+			delete(m.paused, id)
+			continue
+		}
+		if !m.isVisible(id) {
+			continue
+		}
+
+		stopped := m.paused[id]
+		delete(m.paused, id)
+		cmds = append(cmds, m.fetchComponent(id, comp.Config()))
+		if stopped && needsRefresh(comp.Config()) {
+			cmds = append(cmds, m.scheduleSingleRefresh(id, comp.Config()))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// reloadPages replaces the pages after a config reload, pages keep their
+// focus by position and the active page stays selected if it still exists.
+func (m *model) reloadPages() {
+	oldPages := m.pages
+	m.pages = newPages(m.cfg)
+
+	if p := m.currentPage(); p != nil {
+		p.focusedComponentId = m.focusedComponentId
+	}
+
+	for i, p := range m.pages {
+		if i < len(oldPages) && i != m.activePage && p.componentIds[oldPages[i].focusedComponentId] {
+			p.focusedComponentId = oldPages[i].focusedComponentId
+		}
+	}
+
+	if m.activePage >= len(m.pages) {
+		m.activePage = max(0, len(m.pages)-1)
+		if p := m.currentPage(); p != nil {
+			m.focusedComponentId = p.focusedComponentId
+		}
+	}
+}
+
+func (m *model) renderTabs() string {
+	if len(m.pages) < 2 || m.width == 0 {
+		return ""
+	}
+
+	tabs := make([]string, len(m.pages))
+	for i := range m.pages {
+		tabs[i] = m.tabStyle(i).Render(m.tabLabel(i))
+	}
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		MaxWidth(m.width).
+		Render(strings.Join(tabs, ""))
+}
+
+func (m *model) tabLabel(idx int) string {
+	return fmt.Sprintf(" %d %s ", idx+1, m.pages[idx].title)
+}
+
+func (m *model) tabStyle(idx int) lipgloss.Style {
+	if idx == m.activePage {
+		color := components.GetColor(m.cfg.Style.Border.FocusedColor, lipgloss.Color("default"))
+		return lipgloss.NewStyle().Bold(true).Reverse(true).Foreground(color)
+	}
+	return lipgloss.NewStyle().Faint(true)
+}
+
+// tabAt reports whether x, y is on the tab bar and the index of the tab
+// under it, which is -1 for the empty space after the last tab.
+func (m *model) tabAt(x, y int) (int, bool) {
+	if m.renderTabs() == "" {
+		return -1, false
+	}
+
+	row := 0
+	if banner := m.renderBanner(); banner != "" {
+		row = lipgloss.Height(banner)
+	}
+	if y != row {
+		return -1, false
+	}
+
+	offset := 0
+	for i := range m.pages {
+		w := lipgloss.Width(m.tabLabel(i))
+		if x >= offset && x < offset+w {
+			return i, true
+		}
+		offset += w
+	}
+	return -1, true
+}
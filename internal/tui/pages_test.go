@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+const streamPagesDoc = `{"pages": [
+	{"title": "One", "layout": {"type": "component", "component": {"id": "one", "type": "text", "title": "1", "data": {"source": "stream", "command": "sleep 30"}}}},
+	{"title": "Two", "layout": {"type": "component", "component": {"id": "two", "type": "text", "title": "2", "data": {"source": "stream", "command": "sleep 30"}}}}]}`
+
+func TestStreamsRunOnlyOnTheActivePage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dashboard.json")
+	if err := os.WriteFile(path, []byte(streamPagesDoc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := New(cfg, "", nil, nil).(*model)
+	m.Init()
+	defer m.shutdown()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	running := func(id string) bool {
+		_, ok := m.streams[id]
+		return ok
+	}
+
+	if !running("one") || running("two") {
+		t.Fatalf("after start: one running %v, two running %v", running("one"), running("two"))
+	}
+
+	m.switchPage(1)
+	if running("one") || !running("two") {
+		t.Errorf("after switching: one running %v, two running %v", running("one"), running("two"))
+	}
+	if _, paused := m.paused["one"]; !paused {
+		t.Error("the hidden stream is not paused")
+	}
+
+	m.switchPage(0)
+	if !running("one") || running("two") {
+		t.Errorf("after switching back: one running %v, two running %v", running("one"), running("two"))
+	}
+}
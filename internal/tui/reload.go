@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rasjonell/dashbrew/internal/components"
+	"github.com/rasjonell/dashbrew/internal/config"
+)
+
+const (
+	reloadDebounce      = 150 * time.Millisecond
+	maxBannerErrorLines = 5
+)
+
+// Loader loads the dashboard config at path, it is used again on every
+// change of the config file.
+type Loader func(path string) (*config.DashboardConfig, error)
+
+type configReloadMsg struct {
+	cfg *config.DashboardConfig
+	err error
+}
+
+// watchConfig starts watching the directory of the config file, editors
+// often replace the file on save so watching the file itself is not enough.
+func (m *model) watchConfig() tea.Cmd {
+	if m.configPath == "" || m.load == nil {
+		return nil
+	}
+
+	path, err := filepath.Abs(m.configPath)
+	if err != nil {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return func() tea.Msg { return configReloadMsg{err: err} }
+	}
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return func() tea.Msg { return configReloadMsg{err: err} }
+	}
+
+	m.watcher = watcher
+	m.watchedPath = path
+	return waitForConfigChange(watcher, path, m.load)
+}
+
+func waitForConfigChange(watcher *fsnotify.Watcher, path string, load Loader) tea.Cmd {
+	return func() tea.Msg {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return nil
+				}
+				if filepath.Clean(event.Name) != path || !event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename) {
+					continue
+				}
+
+				// Editors tend to emit a burst of events per save.
+				timer := time.NewTimer(reloadDebounce)
+			debounce:
+				for {
+					select {
+					case _, ok := <-watcher.Events:
+						if !ok {
+							timer.Stop()
+							return nil
+						}
+					case <-timer.C:
+						break debounce
+					}
+				}
+
+				cfg, err := load(path)
+				return configReloadMsg{cfg: cfg, err: err}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return nil
+				}
+				return configReloadMsg{err: err}
+			}
+		}
+	}
+}
+
+// applyConfig swaps in a reloaded config. Components whose config did not
+// change keep their state, everything else is rebuilt and fetched again, or
+// once its page is shown.
+func (m *model) applyConfig(msg configReloadMsg) tea.Cmd {
+	if msg.err != nil {
+		m.reloadErr = msg.err
+		m.relayout()
+		return nil
+	}
+
+	m.reloadErr = nil
+	oldCfg := m.cfg
+	oldComponents := m.components
+	rebuildAll := !reflect.DeepEqual(oldCfg.Style, msg.cfg.Style) ||
+		!reflect.DeepEqual(oldCfg.Keybindings, msg.cfg.Keybindings)
+
+	m.cfg = msg.cfg
+	m.keys = newKeyMap(m.cfg.Keybindings[config.GlobalKeyScope])
+	m.components = make(map[string]components.Component)
+	m.reloadPages()
+	for _, p := range m.pages {
+		m.buildComponentMap(p.layout)
+	}
+
+	var cmds []tea.Cmd
+	for id, comp := range m.components {
+		if old, ok := oldComponents[id]; ok && !rebuildAll && reflect.DeepEqual(old.Config(), comp.Config()) {
+			m.components[id] = old
+			continue
+		}
+
+		m.stopStream(id)
+		m.cancelFetch(id)
+		delete(m.paused, id)
+		delete(m.alerts, id)
+		comp = m.restoreHistory(comp)
+		m.components[id] = comp
+		cmds = append(cmds, comp.Init())
+
+		// Components on hidden pages are fetched once their page is shown.
+		if !m.isVisible(id) {
+			m.paused[id] = true
+			continue
+		}
+		cmds = append(cmds, m.fetchComponent(id, comp.Config()))
+		if needsRefresh(comp.Config()) {
+			cmds = append(cmds, m.scheduleSingleRefresh(id, comp.Config()))
+		}
+	}
+
+	for id := range oldComponents {
+		if _, ok := m.components[id]; !ok {
+			m.stopStream(id)
+			m.cancelFetch(id)
+			delete(m.alerts, id)
+		}
+	}
+	m.pauseHiddenStreams()
+
+	m.relayout()
+	return tea.Batch(append(cmds, m.resumeRefreshes())...)
+}
+
+func (m *model) renderBanner() string {
+	if m.reloadErr == nil || m.width == 0 {
+		return ""
+	}
+
+	lines := strings.Split("Config reload failed: "+m.reloadErr.Error(), "\n")
+	if len(lines) > maxBannerErrorLines {
+		lines = append(lines[:maxBannerErrorLines], "...")
+	}
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		MaxWidth(m.width).
+		Bold(true).
+		Foreground(lipgloss.Color("#ffffff")).
+		Background(lipgloss.Color("#aa0000")).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const reloadDoc = `{"layout": {"type": "container", "children": [
+	{"type": "component", "component": {"type": "text", "title": "TITLE", "data": {"source": "script", "command": "uptime"}}},
+	{"type": "component", "component": {"type": "chart", "title": "Load", "data": {"source": "script", "command": "echo 1", "refresh_mode": "append"}}}]}}`
+
+func loadReloadDoc(t *testing.T, title string) *config.DashboardConfig {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "dashboard.json")
+	if err := os.WriteFile(path, []byte(strings.Replace(reloadDoc, "TITLE", title, 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestReloadKeepsComponentsWithoutID(t *testing.T) {
+	m := New(loadReloadDoc(t, "Uptime"), "", nil, nil).(*model)
+	m.Init()
+	defer m.shutdown()
+	m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+
+	const chartID = "layout.children[1].component"
+	const textID = "layout.children[0].component"
+
+	chart, ok := m.components[chartID]
+	if !ok {
+		t.Fatalf("no component %q in %v", chartID, keysOf(m.components))
+	}
+	for _, value := range []string{"1", "2", "3"} {
+		chart, _ = chart.SetContent(data.NewFetchOutput(value, nil))
+	}
+	m.components[chartID] = chart
+	m.focusedComponentId = chartID
+	text := m.components[textID]
+
+	m.applyConfig(configReloadMsg{cfg: loadReloadDoc(t, "Uptime!")})
+
+	if m.components[chartID] != chart {
+		t.Error("the unchanged chart was rebuilt and lost its samples")
+	}
+	if m.components[textID] == text {
+		t.Error("the changed text component was not rebuilt")
+	}
+	if m.focusedComponentId != chartID {
+		t.Errorf("focus moved to %q", m.focusedComponentId)
+	}
+}
+
+func keysOf[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -16,7 +16,7 @@ func (m *model) buildComponentMap(node *config.LayoutNode) {
 	switch node.Type {
 	case "component":
 		if node.Component != nil {
-			comp := components.NewComponent(node.Component, m.cfg.Style)
+			comp := components.NewComponent(node.Component, m.cfg.Style, m.cfg.Keybindings)
 			m.components[comp.ID()] = comp
 		}
 	case "container":
@@ -7,15 +7,18 @@ import (
 	"github.com/rasjonell/dashbrew/internal/config"
 )
 
+// refreshMsg carries the config it was scheduled for so that refresh loops
+// of components replaced by a config reload die out on their own.
 type refreshMsg struct {
-	ID string
+	ID  string
+	cfg *config.Component
 }
 
 func (m *model) scheduleRefreshes() []tea.Cmd {
 	var cmds []tea.Cmd
 
 	for id, comp := range m.components {
-		if comp.Config().Data.RefreshInterval > 0 {
+		if needsRefresh(comp.Config()) {
 			cmds = append(cmds, m.scheduleSingleRefresh(id, comp.Config()))
 		}
 	}
@@ -23,6 +26,10 @@ func (m *model) scheduleRefreshes() []tea.Cmd {
 	return cmds
 }
 
+func needsRefresh(comp *config.Component) bool {
+	return comp.Data != nil && comp.Data.RefreshInterval > 0 && !isStream(comp)
+}
+
 func (m *model) scheduleSingleRefresh(id string, comp *config.Component) tea.Cmd {
 	refreshInterval := 5 // default
 	if comp.Data.RefreshInterval > 0 {
@@ -32,7 +39,8 @@ func (m *model) scheduleSingleRefresh(id string, comp *config.Component) tea.Cmd
 	return func() tea.Msg {
 		time.Sleep(time.Duration(refreshInterval) * time.Second)
 		return refreshMsg{
-			ID: id,
+			ID:  id,
+			cfg: comp,
 		}
 	}
 }
@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
+)
+
+const (
+	defaultStreamBuffer = 200
+	defaultRestartDelay = 1
+)
+
+type streamMsg struct {
+	ID     string
+	Result data.FetchOutput
+	stream *data.Stream
+}
+
+type streamState struct {
+	stream *data.Stream
+	lines  []string
+	header string
+}
+
+func isStream(comp *config.Component) bool {
+	return comp.Data != nil && comp.Data.Source == "stream"
+}
+
+func (m *model) startStream(id string, comp *config.Component) tea.Cmd {
+	m.stopStream(id)
+
+	restartDelay := defaultRestartDelay
+	if comp.Data.RestartDelay > 0 {
+		restartDelay = comp.Data.RestartDelay
+	}
+
+	s := data.StartStream(comp.Data.Command, comp.Data.JSONPath, time.Duration(restartDelay)*time.Second)
+	m.streams[id] = &streamState{stream: s}
+
+	return waitForStream(id, s)
+}
+
+func (m *model) stopStream(id string) {
+	if state, ok := m.streams[id]; ok {
+		state.stream.Stop()
+		delete(m.streams, id)
+	}
+}
+
+// pauseHiddenStreams stops the streams of components on hidden pages, they
+// start again once their page is shown.
+func (m *model) pauseHiddenStreams() {
+	for id := range m.streams {
+		if m.isVisible(id) {
+			continue
+		}
+		m.stopStream(id)
+		if _, paused := m.paused[id]; !paused {
+			m.paused[id] = false
+		}
+	}
+}
+
+func (m *model) stopAllStreams() {
+	for id := range m.streams {
+		m.stopStream(id)
+	}
+}
+
+func waitForStream(id string, s *data.Stream) tea.Cmd {
+	return func() tea.Msg {
+		out, ok := <-s.Output()
+		if !ok {
+			return nil
+		}
+		return streamMsg{ID: id, Result: out, stream: s}
+	}
+}
+
+// handleStreamMsg feeds a single streamed record to its component and keeps
+// listening. Messages from a stream that has since been stopped are dropped.
+func (m *model) handleStreamMsg(msg streamMsg) tea.Cmd {
+	state, ok := m.streams[msg.ID]
+	if !ok || state.stream != msg.stream {
+		return nil
+	}
+
+	comp, ok := m.components[msg.ID]
+	if !ok {
+		return nil
+	}
+
+	recordCmd := m.recordHistory(msg.ID, msg.Result)
+
+	result := msg.Result
+	if result.Error() == nil {
+		limit := defaultStreamBuffer
+		if comp.Config().Data.StreamBuffer > 0 {
+			limit = comp.Config().Data.StreamBuffer
+		}
+
+		line := result.Output()
+		switch {
+		// The header row of a text table is kept out of the buffer so it
+		// isn't dropped with old rows, and not repeated after a restart.
+		case textTableHeader(comp.Config(), line) && state.header == "":
+			state.header = line
+		case state.header != "" && line == state.header:
+		default:
+			state.lines = append(state.lines, line)
+			if len(state.lines) > limit {
+				state.lines = state.lines[len(state.lines)-limit:]
+			}
+		}
+
+		result = data.NewFetchOutput(streamOutput(comp.Config(), state.header, state.lines), nil)
+	}
+
+	updatedComp, cmd := comp.SetContent(result)
+	m.components[msg.ID] = updatedComp
+
+	return tea.Batch(cmd, recordCmd, m.evaluateAlerts(msg.ID, result), waitForStream(msg.ID, msg.stream))
+}
+
+// streamOutput shapes the buffered records into what the component expects:
+// append-mode components get one record at a time, tables of JSON records
+// get them as a JSON array and everything else gets the buffered tail as
+// plain text, after the header row of text tables.
+func streamOutput(comp *config.Component, header string, lines []string) string {
+	switch {
+	case len(lines) == 0:
+		return header
+	case comp.Data.RefreshMode == "append":
+		return lines[len(lines)-1]
+	case comp.Type == "table" && jsonRecords(comp.Data, lines[0]):
+		return "[" + strings.Join(lines, ",") + "]"
+	case header != "":
+		return header + "\n" + strings.Join(lines, "\n")
+	default:
+		return strings.Join(lines, "\n")
+	}
+}
+
+// jsonRecords reports whether a table stream prints JSON records, judged by
+// the configured format or else by its first line.
+func jsonRecords(cfg *config.DataConfig, first string) bool {
+	switch {
+	case cfg.JSONPath != "":
+		return true
+	case cfg.Format != "":
+		return cfg.Format == "json"
+	case cfg.Delimiter != "":
+		return false
+	}
+
+	first = strings.TrimSpace(first)
+	return strings.HasPrefix(first, "{") || strings.HasPrefix(first, "[")
+}
+
+func textTableHeader(comp *config.Component, line string) bool {
+	return comp.Type == "table" && comp.Data.Header && !jsonRecords(comp.Data, line)
+}
@@ -1,26 +1,59 @@
 package tui
 
 import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/fsnotify/fsnotify"
 	"github.com/rasjonell/dashbrew/internal/components"
 	"github.com/rasjonell/dashbrew/internal/config"
+	"github.com/rasjonell/dashbrew/internal/data"
 )
 
 type model struct {
-	cfg *config.DashboardConfig
+	cfg         *config.DashboardConfig
+	configPath  string
+	load        Loader
+	watcher     *fsnotify.Watcher
+	watchedPath string
+	reloadErr   error
+	history     *data.HistoryStore
+	historyErr  error
 
 	width              int
 	height             int
 	ready              bool
 	isAdding           bool
+	showHelp           bool
+	zoomed             bool
 	initialized        bool
 	focusedComponentId string
 
+	pages      []*page
+	activePage int
+
 	components map[string]components.Component
+	// paused holds components on hidden pages that are fetched once their
+	// page is shown, true when their refresh schedule stopped as well.
+	paused     map[string]bool
+	streams    map[string]*streamState
+	inFlight   map[string]*inFlightFetch
+	fetchSeq   uint64
+	selections map[string]*selection
+	alerts     map[string]*componentAlerts
+	flashing   bool
+	flashOn    bool
 
 	componentBoxes map[string]*boundingBox
 	navMap         map[string]*navigationMap
+
+	keys    keyMap
+	help    help.Model
+	toast   *toast
+	confirm *pendingAction
 }
 
 type componentOutput struct {
@@ -29,61 +62,98 @@ type componentOutput struct {
 }
 
 type keyMap struct {
-	Up      key.Binding
-	Add     key.Binding
-	Down    key.Binding
-	Left    key.Binding
-	Quit    key.Binding
-	Right   key.Binding
-	Refresh key.Binding
+	Up       key.Binding
+	Add      key.Binding
+	Down     key.Binding
+	Help     key.Binding
+	Left     key.Binding
+	Quit     key.Binding
+	Right    key.Binding
+	Refresh  key.Binding
+	Zoom     key.Binding
+	NextPage key.Binding
+	PrevPage key.Binding
 }
 
-var keys = keyMap{
-	Quit: key.NewBinding(
-		key.WithKeys("ctrl+c"),
-	),
-	Up: key.NewBinding(
-		key.WithKeys("shift+up", "K"),
-	),
-	Down: key.NewBinding(
-		key.WithKeys("shift+down", "J"),
-	),
-	Left: key.NewBinding(
-		key.WithKeys("shift+left", "H"),
-	),
-	Right: key.NewBinding(
-		key.WithKeys("shift+right", "L"),
-	),
-	Add: key.NewBinding(
-		key.WithKeys("a", "A"),
-	),
-	Refresh: key.NewBinding(
-		key.WithKeys("r", "R"),
-	),
+// newKeyMap builds the global bindings from the resolved "global" scope of
+// the keybindings config.
+func newKeyMap(bindings map[string][]string) keyMap {
+	return keyMap{
+		Quit:    components.NewBinding(bindings["quit"], "quit"),
+		Up:      components.NewBinding(bindings["up"], "focus up"),
+		Down:    components.NewBinding(bindings["down"], "focus down"),
+		Left:    components.NewBinding(bindings["left"], "focus left"),
+		Right:   components.NewBinding(bindings["right"], "focus right"),
+		Add:     components.NewBinding(bindings["add"], "add item"),
+		Refresh: components.NewBinding(bindings["refresh"], "refresh"),
+		Zoom:    components.NewBinding(bindings["zoom"], "toggle zoom"),
+
+		NextPage: components.NewBinding(bindings["next_page"], "next page"),
+		PrevPage: components.NewBinding(bindings["prev_page"], "previous page"),
+		Help:     components.NewBinding(bindings["help"], "toggle help"),
+	}
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Help, k.Quit}
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Left, k.Right},
+		{k.NextPage, k.PrevPage},
+		{k.Add, k.Refresh, k.Zoom, k.Help, k.Quit},
+	}
 }
 
-func New(cfg *config.DashboardConfig) tea.Model {
-	return &model{
+// New creates the dashboard model. When configPath and load are set the
+// config file is watched and reloaded on every change. A nil history store
+// disables recording and restoring of fetch results.
+func New(cfg *config.DashboardConfig, configPath string, load Loader, history *data.HistoryStore) tea.Model {
+	m := &model{
 		cfg:         cfg,
+		configPath:  configPath,
+		load:        load,
+		history:     history,
 		ready:       false,
 		isAdding:    false,
 		initialized: false,
 
 		components: make(map[string]components.Component),
+		paused:     make(map[string]bool),
+		streams:    make(map[string]*streamState),
+		inFlight:   make(map[string]*inFlightFetch),
+		selections: make(map[string]*selection),
+		alerts:     make(map[string]*componentAlerts),
 
 		componentBoxes: make(map[string]*boundingBox),
 		navMap:         make(map[string]*navigationMap),
+
+		help: help.New(),
+	}
+
+	if cfg != nil {
+		m.keys = newKeyMap(cfg.Keybindings[config.GlobalKeyScope])
+		m.pages = newPages(cfg)
 	}
+
+	return m
 }
 
 func (m *model) Init() tea.Cmd {
-	if m.cfg == nil || m.cfg.Layout == nil {
+	if m.cfg == nil || len(m.pages) == 0 {
 		// TODO: error cmd
 		m.initialized = true
 		return nil
 	}
 
-	m.buildComponentMap(m.cfg.Layout)
+	for _, p := range m.pages {
+		m.buildComponentMap(p.layout)
+	}
+
+	for id, comp := range m.components {
+		m.components[id] = m.restoreHistory(comp)
+	}
 
 	if len(m.components) == 0 {
 		// TODO: error cmd
@@ -91,7 +161,7 @@ func (m *model) Init() tea.Cmd {
 		return nil
 	}
 
-	m.focusedComponentId = findFirstComponent(m.cfg.Layout)
+	m.focusedComponentId = m.currentPage().focusedComponentId
 	if m.focusedComponentId == "" && len(m.components) > 0 {
 		for id := range m.components {
 			m.focusedComponentId = id
@@ -109,7 +179,7 @@ func (m *model) Init() tea.Cmd {
 
 	cmds := append(initCmds, fetchCmds...)
 	cmds = append(cmds, refreshCmds...)
-	cmds = append(cmds, tea.ClearScreen)
+	cmds = append(cmds, m.watchConfig(), tea.ClearScreen)
 
 	m.initialized = true
 
@@ -119,7 +189,8 @@ func (m *model) Init() tea.Cmd {
 func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if key.Matches(msg, keys.Quit) {
+		if key.Matches(msg, m.keys.Quit) {
+			m.shutdown()
 			return m, tea.Quit
 		}
 	}
@@ -128,9 +199,11 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	if !m.initialized || m.focusedComponentId == "" {
-		if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		switch msg := msg.(type) {
+		case tea.WindowSizeMsg:
 			m.handleResize(msg.Width, msg.Width)
-		} else {
+		case configReloadMsg:
+		default:
 			return m, nil
 		}
 	}
@@ -142,36 +215,80 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.handleResize(msg.Width, msg.Height)
 
 	case fetchResultMsg:
+		if !m.finishFetch(msg) {
+			break
+		}
 		if comp, ok := m.components[msg.ID]; ok {
 			updatedComp, cmd := comp.SetContent(msg.Result)
 			m.components[msg.ID] = updatedComp
-			cmds = append(cmds, cmd)
+			cmds = append(cmds, cmd, m.recordHistory(msg.ID, msg.Result), m.evaluateAlerts(msg.ID, msg.Result))
+		}
+
+	case streamMsg:
+		cmds = append(cmds, m.handleStreamMsg(msg))
+
+	case configReloadMsg:
+		cmds = append(cmds, m.applyConfig(msg))
+		if m.watcher != nil {
+			cmds = append(cmds, waitForConfigChange(m.watcher, m.watchedPath, m.load))
 		}
 
 	case refreshMsg:
-		if comp, ok := m.components[msg.ID]; ok {
-			fetchCmd := fetchComponentAsyncCmd(comp.ID(), comp.Config())
+		if comp, ok := m.components[msg.ID]; ok && comp.Config() == msg.cfg {
+			if !m.isVisible(msg.ID) {
+				m.paused[msg.ID] = true
+				break
+			}
+			fetchCmd := m.fetchComponent(comp.ID(), comp.Config())
 			rescheduleCmd := m.scheduleSingleRefresh(comp.ID(), comp.Config())
 			cmds = append(cmds, fetchCmd, rescheduleCmd)
 		}
 
 	case tea.MouseMsg:
 		if msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress {
+			if idx, onTabs := m.tabAt(msg.X, msg.Y); onTabs {
+				if idx >= 0 {
+					cmds = append(cmds, m.switchPage(idx))
+				}
+				break
+			}
 			m.focusClicked(msg.X, msg.Y)
 			focusedComp, focusedExists = m.components[m.focusedComponentId]
 		}
 
 		if focusedExists && focusedComp.IsFocusable() {
-			updatedComp, cmd := focusedComp.Update(msg)
+			updatedComp, cmd := focusedComp.Update(m.localMouse(msg))
 			m.components[m.focusedComponentId] = updatedComp
 			cmds = append(cmds, cmd)
 		}
 
+	case actionResultMsg:
+		cmds = append(cmds, m.handleActionResult(msg))
+
+	case historyRecordedMsg:
+		cmds = append(cmds, m.handleHistoryRecorded(msg))
+
+	case alertFlashMsg:
+		cmds = append(cmds, m.handleAlertFlash())
+
+	case alertHookMsg:
+		cmds = append(cmds, m.showToast("Alert hook for "+msg.title+" failed: "+msg.err.Error(), true))
+
+	case clearToastMsg:
+		if m.toast != nil && m.toast.seq == msg.seq {
+			m.toast = nil
+		}
+
 	case tea.KeyMsg:
-		if key.Matches(msg, keys.Quit) {
+		if key.Matches(msg, m.keys.Quit) {
+			m.shutdown()
 			return m, tea.Quit
 		}
 
+		if m.confirm != nil {
+			return m, tea.Batch(append(cmds, m.handleConfirmKey(msg))...)
+		}
+
 		if m.isAdding {
 			if focusedExists && focusedComp.SupportsAdd() {
 				var existAddMode bool
@@ -190,36 +307,60 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
-		switch {
-		case key.Matches(msg, keys.Up):
-			if nav, ok := m.navMap[m.focusedComponentId]; ok && nav.Up != "" {
-				m.tryFocus(nav.Up)
-			}
-		case key.Matches(msg, keys.Down):
-			if nav, ok := m.navMap[m.focusedComponentId]; ok && nav.Down != "" {
-				m.tryFocus(nav.Down)
-			}
-		case key.Matches(msg, keys.Left):
-			if nav, ok := m.navMap[m.focusedComponentId]; ok && nav.Left != "" {
-				m.tryFocus(nav.Left)
-			}
-		case key.Matches(msg, keys.Right):
-			if nav, ok := m.navMap[m.focusedComponentId]; ok && nav.Right != "" {
-				m.tryFocus(nav.Right)
+		// Keys belong to the component while it is reading text, e.g. a
+		// list filter, otherwise typing "a" or "L" would trigger a global.
+		if focusedExists && focusedComp.CapturesInput() {
+			updatedComp, cmd := focusedComp.Update(msg)
+			m.components[m.focusedComponentId] = updatedComp
+			cmds = append(cmds, cmd)
+			return m, tea.Batch(append(cmds, m.followSelections()...)...)
+		}
+
+		if m.showHelp {
+			switch {
+			case key.Matches(msg, m.keys.Help), msg.Type == tea.KeyEsc:
+				m.showHelp = false
+			case key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Left, m.keys.Right):
+				m.moveFocus(msg)
 			}
+			return m, tea.Batch(cmds...)
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Left, m.keys.Right):
+			m.moveFocus(msg)
 
-		case key.Matches(msg, keys.Add):
+		case key.Matches(msg, m.keys.Help):
+			m.showHelp = true
+
+		case key.Matches(msg, m.keys.NextPage):
+			cmds = append(cmds, m.switchPage(m.activePage+1))
+
+		case key.Matches(msg, m.keys.PrevPage):
+			cmds = append(cmds, m.switchPage(m.activePage-1))
+
+		case key.Matches(msg, m.keys.Zoom):
+			m.zoomed = !m.zoomed
+			m.relayout()
+
+		case key.Matches(msg, m.keys.Add):
 			if focusedExists && focusedComp.SupportsAdd() {
 				m.isAdding = true
 			}
 
-		case key.Matches(msg, keys.Refresh):
+		case key.Matches(msg, m.keys.Refresh):
 			if focusedExists && focusedComp.SupportsRefresh() {
-				cmd = fetchComponentAsyncCmd(focusedComp.ID(), focusedComp.Config())
+				cmd = m.refetchComponent(focusedComp.ID(), focusedComp.Config())
 				cmds = append(cmds, cmd)
 			}
 
 		default:
+			if focusedExists {
+				if cmd, ok := m.startAction(focusedComp, msg); ok {
+					cmds = append(cmds, cmd)
+					break
+				}
+			}
 			if focusedExists && focusedComp.IsFocusable() {
 				updatedComp, cmd := focusedComp.Update(msg)
 				m.components[m.focusedComponentId] = updatedComp
@@ -228,6 +369,8 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	cmds = append(cmds, m.followSelections()...)
+
 	if !m.ready && m.width > 0 && m.height > 0 {
 		m.ready = true
 	}
@@ -235,6 +378,15 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// shutdown stops every running stream and fetch before quitting.
+func (m *model) shutdown() {
+	m.stopAllStreams()
+	m.cancelAllFetches()
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+}
+
 func (m *model) View() string {
 	if m.cfg == nil {
 		return "Error: no config loaded."
@@ -246,9 +398,31 @@ func (m *model) View() string {
 		return "Resizing..."
 	}
 
-	return m.renderNode(
-		m.cfg.Layout,
-		m.width, m.height,
-		m.focusedComponentId,
-	)
+	_, w, h := m.layoutArea()
+
+	var body string
+	if m.showHelp {
+		body = m.renderHelp(w, h)
+	} else {
+		body = m.renderNode(
+			m.layoutRoot(),
+			w, h,
+			m.focusedComponentId,
+		)
+	}
+
+	var blocks []string
+	for _, block := range []string{m.renderBanner(), m.renderTabs(), m.renderAlerts(), body} {
+		if block != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	view := lipgloss.JoinVertical(lipgloss.Left, blocks...)
+
+	if status := m.renderStatus(); status != "" {
+		lines := strings.Split(view, "\n")
+		lines[len(lines)-1] = status
+		view = strings.Join(lines, "\n")
+	}
+	return view
 }